@@ -4,19 +4,11 @@ go 1.24.2
 
 require github.com/stretchr/testify v1.10.0
 
-require (
-	github.com/getsentry/sentry-go v0.20.0 // indirect
-	github.com/golang-jwt/jwt v3.2.2+incompatible // indirect
-	github.com/google/uuid v1.3.0 // indirect
-	golang.org/x/net v0.33.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
-)
+require golang.org/x/net v0.33.0 // indirect
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/go-resty/resty/v2 v2.16.5
-	github.com/pericles-luz/go-base v0.11.5
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
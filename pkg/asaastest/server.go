@@ -0,0 +1,767 @@
+// Package asaastest provides an in-process fake of the Asaas API for
+// hermetic tests. It keeps customers, subscriptions and payments in memory,
+// answers with Asaas-shaped IDs and error bodies, and can inject latency and
+// failures.
+package asaastest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/boleto"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/pix"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+)
+
+const (
+	API_KEY = "$aact_asaastest"
+	// bank of the boletos issued by the server
+	BANK_CODE = "461"
+
+	RESOURCE_CUSTOMERS     = "customers"
+	RESOURCE_SUBSCRIPTIONS = "subscriptions"
+	RESOURCE_PAYMENTS      = "payments"
+	RESOURCE_NOTIFICATIONS = "notifications"
+)
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string // as sent, with IDs still escaped
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// Fault makes the server answer the matching requests with Status instead
+// of handling them. Empty Method and PathPrefix match every request.
+type Fault struct {
+	Method     string
+	PathPrefix string
+	Status     int
+	Body       string
+	RetryAfter string
+	Latency    time.Duration
+	// how many requests fail; zero or less fails every matching request
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+	return strings.HasPrefix(r.URL.Path, f.PathPrefix)
+}
+
+// Server is the fake Asaas API
+type Server struct {
+	mutex     sync.Mutex
+	server    *httptest.Server
+	apiKey    string
+	latency   time.Duration
+	faults    []*Fault
+	requests  []Request
+	resources map[string]map[string]map[string]interface{}
+	order     map[string][]string
+	handlers  []route
+}
+
+// a handler of the paths that match pattern, where * is an ID
+type route struct {
+	method  string
+	pattern []string
+	handle  func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{})
+}
+
+// starts a fake server that accepts API_KEY as access token
+func NewServer() *Server {
+	s := &Server{
+		apiKey:    API_KEY,
+		resources: map[string]map[string]map[string]interface{}{},
+		order:     map[string][]string{},
+	}
+	s.routes()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// the link to be used as the credential link or base URL
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// defines the access token accepted by the server
+func (s *Server) SetAPIKey(apiKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.apiKey = apiKey
+}
+
+// returns a credential accepted by the server
+func (s *Server) Credential() *model.Credential {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return model.NewCredential().SetAccessToken(s.apiKey).SetLink(s.server.URL)
+}
+
+// creates a client pointing to the server. Options are applied after the
+// server credential, so they can override it.
+func (s *Server) Client(opts ...rest_asaas.Option) (*rest_asaas.Rest, error) {
+	provider, err := rest_asaas.NewStaticCredentialProvider(s.Credential())
+	if err != nil {
+		return nil, err
+	}
+	opts = append([]rest_asaas.Option{rest_asaas.WithCredentialProvider(provider)}, opts...)
+	return rest_asaas.NewClient(opts...)
+}
+
+// delays every answer
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latency = latency
+}
+
+// adds a fault, checked in the order faults were added
+func (s *Server) InjectFault(fault Fault) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// makes the next requests fail with the status
+func (s *Server) FailNext(status int, times int) {
+	s.InjectFault(Fault{Status: status, Times: times})
+}
+
+// makes the next requests fail with 429 and the given Retry-After
+func (s *Server) RateLimitNext(times int, retryAfter time.Duration) {
+	s.InjectFault(Fault{
+		Status:     http.StatusTooManyRequests,
+		RetryAfter: strconv.Itoa(int(retryAfter.Seconds())),
+		Times:      times,
+	})
+}
+
+// removes every fault and latency
+func (s *Server) ClearFaults() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = nil
+	s.latency = 0
+}
+
+// returns every request received, in order
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// stores an object as if it had been created through the API, returning its ID
+func (s *Server) Seed(resource string, object map[string]interface{}) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.store(resource, object)
+}
+
+// returns a copy of a stored object
+func (s *Server) Get(resource string, id string) (map[string]interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	object, ok := s.resources[resource][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	raw := readBody(r)
+	fault, latency := s.record(r, raw)
+	if latency > 0 {
+		if err := sleep(r.Context(), latency); err != nil {
+			return
+		}
+	}
+	if fault != nil {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeRaw(w, fault.Status, fault.Body)
+		return
+	}
+	if r.Header.Get("access_token") != s.getAPIKey() {
+		writeRaw(w, http.StatusUnauthorized, "")
+		return
+	}
+	body := map[string]interface{}{}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &body); err != nil {
+			writeErrors(w, http.StatusBadRequest, "invalid_object", "JSON inválido.")
+			return
+		}
+	}
+	// splits before unescaping, so an ID holding a slash stays a single part
+	parts := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, part := range parts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			parts[i] = unescaped
+		}
+	}
+	for _, handler := range s.handlers {
+		if ids, ok := match(handler, r.Method, parts); ok {
+			handler.handle(w, r, ids, body)
+			return
+		}
+	}
+	writeRaw(w, http.StatusNotFound, "")
+}
+
+// records the request and picks the fault that answers it
+func (s *Server) record(r *http.Request, body string) (*Fault, time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.EscapedPath(),
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	latency := s.latency
+	for i, fault := range s.faults {
+		if !fault.matches(r) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		copied := *fault
+		return &copied, latency + fault.Latency
+	}
+	return nil, latency
+}
+
+func (s *Server) getAPIKey() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.apiKey
+}
+
+func match(handler route, method string, parts []string) ([]string, bool) {
+	if handler.method != method || len(handler.pattern) != len(parts) {
+		return nil, false
+	}
+	ids := []string{}
+	for i, part := range handler.pattern {
+		if part == "*" {
+			ids = append(ids, parts[i])
+			continue
+		}
+		if part != parts[i] {
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+// stores the object with a new ID; must be called with the lock held
+func (s *Server) store(resource string, object map[string]interface{}) string {
+	if s.resources[resource] == nil {
+		s.resources[resource] = map[string]map[string]interface{}{}
+	}
+	id, _ := object["id"].(string)
+	if id == "" {
+		id = newID(resource)
+	}
+	object = copyObject(object)
+	object["id"] = id
+	object["object"] = strings.TrimSuffix(resource, "s")
+	if _, ok := object["dateCreated"]; !ok {
+		object["dateCreated"] = model.Today().String()
+	}
+	if _, ok := object["deleted"]; !ok {
+		object["deleted"] = false
+	}
+	_, exists := s.resources[resource][id]
+	if !exists {
+		s.order[resource] = append(s.order[resource], id)
+	}
+	s.resources[resource][id] = object
+	if !exists && resource == RESOURCE_CUSTOMERS {
+		s.storeNotifications(id)
+	}
+	return id
+}
+
+// notifications Asaas creates with every customer: warnings before and on
+// the due date, and overdue notices on the day and every 7 days after it
+var defaultNotifications = []struct {
+	event  string
+	offset int
+}{
+	{"PAYMENT_CREATED", 0},
+	{"PAYMENT_UPDATED", 0},
+	{"PAYMENT_RECEIVED", 0},
+	{"PAYMENT_OVERDUE", 0},
+	{"PAYMENT_OVERDUE", 7},
+	{"PAYMENT_DUEDATE_WARNING", 10},
+	{"PAYMENT_DUEDATE_WARNING", 0},
+	{"SEND_LINHA_DIGITAVEL", 0},
+}
+
+// creates the default notifications of a customer; must be called with the lock held
+func (s *Server) storeNotifications(customerID string) {
+	for _, notification := range defaultNotifications {
+		event := notification.event
+		s.store(RESOURCE_NOTIFICATIONS, map[string]interface{}{
+			"customer":                    customerID,
+			"event":                       event,
+			"enabled":                     true,
+			"emailEnabledForProvider":     event == "PAYMENT_RECEIVED",
+			"smsEnabledForProvider":       false,
+			"emailEnabledForCustomer":     true,
+			"smsEnabledForCustomer":       true,
+			"phoneCallEnabledForCustomer": false,
+			"whatsappEnabledForCustomer":  false,
+			"scheduleOffset":              notification.offset,
+		})
+	}
+}
+
+// lists the notifications of the customer in the path
+func (s *Server) listNotifications(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	if _, ok := s.Get(RESOURCE_CUSTOMERS, ids[0]); !ok {
+		writeRaw(w, http.StatusNotFound, "")
+		return
+	}
+	query := r.URL.Query()
+	query.Set("customer", ids[0])
+	r.URL.RawQuery = query.Encode()
+	s.list(RESOURCE_NOTIFICATIONS)(w, r, ids, body)
+}
+
+// updates several notifications of a customer at once
+func (s *Server) updateNotifications(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	customerID, _ := body["customer"].(string)
+	items, _ := body["notifications"].([]interface{})
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.resources[RESOURCE_CUSTOMERS][customerID]; !ok {
+		writeErrors(w, http.StatusBadRequest, "invalid_customer", "Cliente inexistente.")
+		return
+	}
+	updated := []map[string]interface{}{}
+	for _, item := range items {
+		fields, _ := item.(map[string]interface{})
+		id, _ := fields["id"].(string)
+		notification, ok := s.resources[RESOURCE_NOTIFICATIONS][id]
+		if !ok || notification["customer"] != customerID {
+			writeErrors(w, http.StatusBadRequest, "invalid_notification", "Notificação "+id+" não pertence ao cliente.")
+			return
+		}
+		for key, value := range fields {
+			notification[key] = value
+		}
+		updated = append(updated, copyObject(notification))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"notifications": updated})
+}
+
+// answers the digitable line and barcode of a boleto payment, built from
+// its due date, value and nosso número
+func (s *Server) identificationField(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	payment, ok := s.resources[RESOURCE_PAYMENTS][ids[0]]
+	if !ok || payment["deleted"] == true {
+		writeRaw(w, http.StatusNotFound, "")
+		return
+	}
+	if billingType := payment["billingType"]; billingType != "BOLETO" && billingType != "UNDEFINED" {
+		writeErrors(w, http.StatusBadRequest, "invalid_action", "A linha digitável só existe para cobranças por boleto.")
+		return
+	}
+	if isEmpty(payment["nossoNumero"]) {
+		number, _ := rand.Int(rand.Reader, big.NewInt(100_000_000))
+		payment["nossoNumero"] = strconv.FormatInt(number.Int64(), 10)
+	}
+	nossoNumero := payment["nossoNumero"].(string)
+	dueDate, _ := model.ParseDate(fmt.Sprintf("%v", payment["dueDate"]))
+	value, _ := model.ParseMoney(fmt.Sprintf("%v", payment["value"]))
+	number, _ := strconv.ParseInt(nossoNumero, 10, 64)
+	barcode, err := boleto.NewBarcode(BANK_CODE, boleto.DueFactor(dueDate.Time()), value.Cents(), fmt.Sprintf("%025d", number))
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "invalid_action", "Não foi possível gerar a linha digitável.")
+		return
+	}
+	line, _ := boleto.LineOf(barcode)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"identificationField": line,
+		"nossoNumero":         nossoNumero,
+		"barCode":             barcode,
+	})
+}
+
+// answers the QR code of a PIX payment. The payload is a valid BR Code; the
+// image is a placeholder PNG, not a scannable code.
+func (s *Server) pixQrCode(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	payment, ok := s.Get(RESOURCE_PAYMENTS, ids[0])
+	if !ok || payment["deleted"] == true {
+		writeRaw(w, http.StatusNotFound, "")
+		return
+	}
+	if payment["billingType"] == "CREDIT_CARD" {
+		writeErrors(w, http.StatusBadRequest, "invalid_action", "O QR Code Pix não está disponível para cobranças por cartão.")
+		return
+	}
+	value, _ := model.ParseMoney(fmt.Sprintf("%v", payment["value"]))
+	txID := strings.ReplaceAll(ids[0], "_", "")
+	payload, err := pix.Build(
+		pix.Field{ID: pix.ID_PAYLOAD_FORMAT, Value: pix.PAYLOAD_FORMAT},
+		pix.Field{ID: pix.ID_POINT_OF_INITIATION, Value: pix.POINT_OF_INITIATION_UNIQUE},
+		pix.Field{ID: pix.ID_MERCHANT_ACCOUNT, Value: pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_GUI, Value: pix.PIX_GUI}) +
+			pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_URL, Value: strings.TrimPrefix(s.URL(), "http://") + "/qr/" + txID})},
+		pix.Field{ID: pix.ID_MERCHANT_CATEGORY_CODE, Value: "0000"},
+		pix.Field{ID: pix.ID_TRANSACTION_CURRENCY, Value: pix.CURRENCY_REAL},
+		pix.Field{ID: pix.ID_TRANSACTION_AMOUNT, Value: value.String()},
+		pix.Field{ID: pix.ID_COUNTRY_CODE, Value: "BR"},
+		pix.Field{ID: pix.ID_MERCHANT_NAME, Value: "ASAASTEST"},
+		pix.Field{ID: pix.ID_MERCHANT_CITY, Value: "BELO HORIZONTE"},
+		pix.Field{ID: pix.ID_ADDITIONAL_DATA, Value: pix.Encode(pix.Field{ID: pix.ID_ADDITIONAL_DATA_TXID, Value: txID})},
+	)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "invalid_action", "Não foi possível gerar o QR Code Pix.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"encodedImage":   base64.StdEncoding.EncodeToString(placeholderImage(payload)),
+		"payload":        payload,
+		"expirationDate": fmt.Sprintf("%v 23:59:59", payment["dueDate"]),
+	})
+}
+
+// draws the bits of data in a square PNG
+func placeholderImage(data string) []byte {
+	const size = 32
+	canvas := image.NewGray(image.Rect(0, 0, size, size))
+	for i := 0; i < size*size; i++ {
+		if data[(i/8)%len(data)]>>(i%8)&1 == 0 {
+			canvas.Pix[i] = 0xFF
+		}
+	}
+	var buffer bytes.Buffer
+	_ = png.Encode(&buffer, canvas)
+	return buffer.Bytes()
+}
+
+func (s *Server) create(resource string, required []string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		for _, field := range required {
+			if isEmpty(body[field]) {
+				writeErrors(w, http.StatusBadRequest, "invalid_"+field, "O campo "+field+" deve ser informado.")
+				return
+			}
+		}
+		s.mutex.Lock()
+		if customerID, ok := body["customer"].(string); ok && resource != RESOURCE_CUSTOMERS {
+			if _, exists := s.resources[RESOURCE_CUSTOMERS][customerID]; !exists {
+				s.mutex.Unlock()
+				writeErrors(w, http.StatusBadRequest, "invalid_customer", "Cliente inexistente.")
+				return
+			}
+		}
+		id := s.store(resource, s.defaults(resource, body))
+		object := copyObject(s.resources[resource][id])
+		s.mutex.Unlock()
+		writeJSON(w, http.StatusOK, object)
+	}
+}
+
+// fills the fields Asaas sets on creation
+func (s *Server) defaults(resource string, body map[string]interface{}) map[string]interface{} {
+	object := copyObject(body)
+	switch resource {
+	case RESOURCE_CUSTOMERS:
+		if isEmpty(object["personType"]) {
+			object["personType"] = "FISICA"
+			if document, _ := object["cpfCnpj"].(string); len(document) == 14 {
+				object["personType"] = "JURIDICA"
+			}
+		}
+	case RESOURCE_SUBSCRIPTIONS:
+		object["status"] = "ACTIVE"
+	case RESOURCE_PAYMENTS:
+		object["status"] = "PENDING"
+		object["netValue"] = object["value"]
+		if _, ok := object["originalDueDate"]; !ok {
+			object["originalDueDate"] = object["dueDate"]
+		}
+	}
+	return object
+}
+
+func (s *Server) retrieve(resource string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		object, ok := s.Get(resource, ids[0])
+		if !ok {
+			writeRaw(w, http.StatusNotFound, "")
+			return
+		}
+		writeJSON(w, http.StatusOK, object)
+	}
+}
+
+func (s *Server) update(resource string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		s.mutex.Lock()
+		object, ok := s.resources[resource][ids[0]]
+		if !ok {
+			s.mutex.Unlock()
+			writeRaw(w, http.StatusNotFound, "")
+			return
+		}
+		if object["deleted"] == true {
+			s.mutex.Unlock()
+			writeErrors(w, http.StatusBadRequest, "invalid_action", "Não é possível alterar um registro removido.")
+			return
+		}
+		for key, value := range body {
+			if key != "id" && key != "object" {
+				object[key] = value
+			}
+		}
+		result := copyObject(object)
+		s.mutex.Unlock()
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func (s *Server) remove(resource string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		s.mutex.Lock()
+		object, ok := s.resources[resource][ids[0]]
+		if ok {
+			object["deleted"] = true
+		}
+		s.mutex.Unlock()
+		if !ok {
+			writeRaw(w, http.StatusNotFound, "")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": true, "id": ids[0]})
+	}
+}
+
+func (s *Server) restore(resource string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		s.mutex.Lock()
+		object, ok := s.resources[resource][ids[0]]
+		if ok {
+			object["deleted"] = false
+		}
+		var result map[string]interface{}
+		if ok {
+			result = copyObject(object)
+		}
+		s.mutex.Unlock()
+		if !ok {
+			writeRaw(w, http.StatusNotFound, "")
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// lists the objects that are not deleted and whose fields equal every query
+// parameter besides offset and limit
+func (s *Server) list(resource string) func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+	return func(w http.ResponseWriter, r *http.Request, ids []string, body map[string]interface{}) {
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = model.DEFAULT_LIST_LIMIT
+		}
+		if limit > model.MAX_LIST_LIMIT {
+			writeErrors(w, http.StatusBadRequest, "invalid_limit", "O limite máximo é 100.")
+			return
+		}
+		s.mutex.Lock()
+		matched := []map[string]interface{}{}
+		for _, id := range s.order[resource] {
+			object := s.resources[resource][id]
+			if object["deleted"] == true || !matchesQuery(object, query) {
+				continue
+			}
+			matched = append(matched, copyObject(object))
+		}
+		s.mutex.Unlock()
+		page := []map[string]interface{}{}
+		if offset < len(matched) {
+			page = matched[offset:min(offset+limit, len(matched))]
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"object":     "list",
+			"hasMore":    offset+limit < len(matched),
+			"totalCount": len(matched),
+			"limit":      limit,
+			"offset":     offset,
+			"data":       page,
+		})
+	}
+}
+
+func (s *Server) routes() {
+	resources := map[string][]string{
+		RESOURCE_CUSTOMERS:     {"name", "cpfCnpj"},
+		RESOURCE_SUBSCRIPTIONS: {"customer", "billingType", "value", "nextDueDate", "cycle"},
+		RESOURCE_PAYMENTS:      {"customer", "billingType", "value", "dueDate"},
+	}
+	s.handlers = append(s.handlers,
+		route{http.MethodGet, []string{"v3", RESOURCE_CUSTOMERS, "*", RESOURCE_NOTIFICATIONS}, s.listNotifications},
+		route{http.MethodPut, []string{"v3", RESOURCE_NOTIFICATIONS, "batch"}, s.updateNotifications},
+		route{http.MethodPut, []string{"v3", RESOURCE_NOTIFICATIONS, "*"}, s.update(RESOURCE_NOTIFICATIONS)},
+		route{http.MethodGet, []string{"v3", RESOURCE_PAYMENTS, "*", "identificationField"}, s.identificationField},
+		route{http.MethodGet, []string{"v3", RESOURCE_PAYMENTS, "*", "pixQrCode"}, s.pixQrCode},
+	)
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s.handlers = append(s.handlers,
+			route{http.MethodPost, []string{"v3", name}, s.create(name, resources[name])},
+			route{http.MethodGet, []string{"v3", name}, s.list(name)},
+			route{http.MethodGet, []string{"v3", name, "*"}, s.retrieve(name)},
+			route{http.MethodPut, []string{"v3", name, "*"}, s.update(name)},
+			route{http.MethodDelete, []string{"v3", name, "*"}, s.remove(name)},
+			route{http.MethodPost, []string{"v3", name, "*", "restore"}, s.restore(name)},
+		)
+	}
+}
+
+func matchesQuery(object map[string]interface{}, query map[string][]string) bool {
+	for key, values := range query {
+		if key == "offset" || key == "limit" || len(values) == 0 {
+			continue
+		}
+		// ranges like dueDate[ge] compare dates, which sort as strings
+		if field, ok := strings.CutSuffix(key, "[ge]"); ok {
+			if isEmpty(object[field]) || fmt.Sprintf("%v", object[field]) < values[0] {
+				return false
+			}
+			continue
+		}
+		if field, ok := strings.CutSuffix(key, "[le]"); ok {
+			if isEmpty(object[field]) || fmt.Sprintf("%v", object[field]) > values[0] {
+				return false
+			}
+			continue
+		}
+		if fmt.Sprintf("%v", object[key]) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+var idAlphabet = []byte("abcdefghijklmnopqrstuvwxyz0123456789")
+
+// generates IDs shaped like the Asaas ones: cus_000006724433, sub_1ifrhps9m8mwficw
+func newID(resource string) string {
+	if resource == RESOURCE_CUSTOMERS {
+		number, _ := rand.Int(rand.Reader, big.NewInt(1_000_000_000_000))
+		return fmt.Sprintf("cus_%012d", number.Int64())
+	}
+	prefix := resource[:3]
+	result := make([]byte, 16)
+	for i := range result {
+		index, _ := rand.Int(rand.Reader, big.NewInt(int64(len(idAlphabet))))
+		result[i] = idAlphabet[index.Int64()]
+	}
+	return prefix + "_" + string(result)
+}
+
+func isEmpty(value interface{}) bool {
+	switch t := value.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	}
+	return false
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		result[key] = value
+	}
+	return result
+}
+
+func readBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	data, _ := io.ReadAll(r.Body)
+	return string(data)
+}
+
+func writeRaw(w http.ResponseWriter, status int, body string) {
+	if body != "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	data, _ := json.Marshal(value)
+	writeRaw(w, status, string(data))
+}
+
+// writes an error body shaped like the Asaas ones
+func writeErrors(w http.ResponseWriter, status int, code string, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{{"code": code, "description": description}},
+	})
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package asaastest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, opts ...rest_asaas.Option) (*asaastest.Server, *rest_asaas.Rest) {
+	t.Helper()
+	server := asaastest.NewServer()
+	t.Cleanup(server.Close)
+	restEntity, err := server.Client(opts...)
+	require.NoError(t, err, "should create client")
+	return server, restEntity
+}
+
+func newCustomer() *model.Customer {
+	return model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+}
+
+func TestServerShouldGenerateAsaasShapedIDs(t *testing.T) {
+	server, restEntity := newServer(t)
+	customer, err := restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^cus_\d{12}$`), customer.ID)
+	subscriptionID := server.Seed(asaastest.RESOURCE_SUBSCRIPTIONS, map[string]interface{}{"customer": customer.ID})
+	require.Regexp(t, regexp.MustCompile(`^sub_[a-z0-9]{16}$`), subscriptionID)
+	paymentID := server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{"customer": customer.ID})
+	require.Regexp(t, regexp.MustCompile(`^pay_[a-z0-9]{16}$`), paymentID)
+}
+
+func TestServerShouldKeepStateInMemory(t *testing.T) {
+	_, restEntity := newServer(t)
+	created, err := restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err)
+	retrieved, err := restEntity.GetCustomer(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, retrieved.ID)
+	require.Equal(t, model.PERSON_TYPE_FISICA, retrieved.PersonType, "person type should be inferred from the document")
+	_, err = restEntity.GetCustomer(context.Background(), "cus_000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound)
+}
+
+func TestServerShouldPaginate(t *testing.T) {
+	server, restEntity := newServer(t)
+	for i := 0; i < 25; i++ {
+		server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{"name": "John Doe", "cpfCnpj": "00000000191"})
+	}
+	customers, err := restEntity.ListAllCustomers(context.Background(), model.NewCustomerFilter().SetLimit(10), 0)
+	require.NoError(t, err)
+	require.Len(t, customers, 25)
+	page, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetOffset(20))
+	require.NoError(t, err)
+	require.Len(t, page.Data, 5)
+	require.False(t, page.HasMore)
+	require.Equal(t, 25, page.TotalCount)
+}
+
+func TestServerShouldAnswerWithAsaasErrorBodies(t *testing.T) {
+	_, restEntity := newServer(t)
+	subscription := model.NewSubscription().
+		SetCustomerID("cus_000000000000").
+		SetBillingType(model.BILLING_TYPE_BOLETO).
+		SetNextDueDate(model.Today().AddDate(0, 1, 0)).
+		SetValue(model.Reais(100)).
+		SetCycle(model.CYCLE_MONTHLY)
+	_, err := restEntity.Subscribe(context.Background(), subscription)
+	var apiError *rest_asaas.APIError
+	require.True(t, errors.As(err, &apiError), "error should be an APIError")
+	require.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+	require.True(t, apiError.HasCode("invalid_customer"))
+}
+
+func TestServerShouldRejectUnknownAccessToken(t *testing.T) {
+	server, restEntity := newServer(t)
+	server.SetAPIKey("$aact_other")
+	_, err := restEntity.GetCustomer(context.Background(), "cus_000000000000")
+	var apiError *rest_asaas.APIError
+	require.True(t, errors.As(err, &apiError), "error should be an APIError")
+	require.Equal(t, http.StatusUnauthorized, apiError.StatusCode)
+}
+
+func TestServerShouldInjectFailures(t *testing.T) {
+	server, restEntity := newServer(t, rest_asaas.WithRetryPolicy(rest_asaas.RetryPolicy{
+		MaxAttempts:      3,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         time.Millisecond,
+		RetryableStatus:  map[int]bool{http.StatusTooManyRequests: true, http.StatusInternalServerError: true},
+		RetryableMethods: map[string]bool{http.MethodGet: true},
+	}))
+	customerID := server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{"name": "John Doe", "cpfCnpj": "00000000191", "email": "john@example.com"})
+	server.FailNext(http.StatusInternalServerError, 1)
+	server.RateLimitNext(1, 0)
+	customer, err := restEntity.GetCustomer(context.Background(), customerID)
+	require.NoError(t, err, "transient failures should be retried")
+	require.Equal(t, customerID, customer.ID)
+	require.Len(t, server.Requests(), 3)
+	server.FailNext(http.StatusInternalServerError, 0)
+	_, err = restEntity.GetCustomer(context.Background(), customerID)
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerRetrievalFailed)
+	server.ClearFaults()
+	_, err = restEntity.GetCustomer(context.Background(), customerID)
+	require.NoError(t, err)
+}
+
+func TestServerShouldInjectFaultsByEndpoint(t *testing.T) {
+	server, restEntity := newServer(t, rest_asaas.WithRetryPolicy(rest_asaas.NoRetryPolicy()))
+	server.InjectFault(asaastest.Fault{Method: http.MethodPost, PathPrefix: "/v3/subscriptions", Status: http.StatusServiceUnavailable})
+	_, err := restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err, "other endpoints should not fail")
+}
+
+func TestServerShouldInjectLatency(t *testing.T) {
+	server, restEntity := newServer(t, rest_asaas.WithRetryPolicy(rest_asaas.NoRetryPolicy()))
+	server.SetLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := restEntity.GetCustomer(ctx, "cus_000000000000")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,266 @@
+// Package boleto validates and converts the digitable line (linha digitável)
+// and barcode of bank boletos, following the FEBRABAN layout: three fields
+// checked with modulo 10 and a general check digit computed with modulo 11.
+package boleto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidLine    = errors.New("invalid boleto digitable line")
+	ErrInvalidBarcode = errors.New("invalid boleto barcode")
+	ErrLineMismatch   = errors.New("digitable line does not match the barcode")
+	ErrInvalidField   = errors.New("invalid boleto field")
+)
+
+const (
+	LINE_LENGTH    = 47
+	BARCODE_LENGTH = 44
+
+	CURRENCY_REAL = "9"
+
+	// due factors restart at 1000 after reaching 9999, every 9000 days
+	MIN_DUE_FACTOR = 1000
+	MAX_DUE_FACTOR = 9999
+	MAX_VALUE      = 9_999_999_999
+)
+
+// day of due factor zero
+var factorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+// Boleto is the data encoded in a barcode
+type Boleto struct {
+	Bank      string
+	Currency  string
+	DueFactor int   // zero when the boleto has no due date
+	Value     int64 // centavos, zero when the payer chooses the value
+	FreeField string
+	Barcode   string
+	Line      string
+}
+
+// removes the dots and spaces of a formatted line. Other characters are
+// kept so validation can reject them.
+func Normalize(value string) string {
+	var builder strings.Builder
+	for _, char := range value {
+		switch char {
+		case '.', ' ', '-', '\t', '\n':
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// validates the length, the three field check digits and the general check
+// digit of a digitable line, formatted or not
+func ValidateLine(line string) error {
+	line = Normalize(line)
+	if len(line) != LINE_LENGTH || !isNumeric(line) {
+		return ErrInvalidLine
+	}
+	fields := [][2]int{{0, 9}, {10, 20}, {21, 31}}
+	for _, field := range fields {
+		if mod10(line[field[0]:field[1]]) != line[field[1]] {
+			return ErrInvalidLine
+		}
+	}
+	if ValidateBarcode(barcodeOf(line)) != nil {
+		return ErrInvalidLine
+	}
+	return nil
+}
+
+// validates the length and the general check digit of a barcode
+func ValidateBarcode(barcode string) error {
+	if len(barcode) != BARCODE_LENGTH || !isNumeric(barcode) {
+		return ErrInvalidBarcode
+	}
+	if mod11(barcode[:4]+barcode[5:]) != barcode[4] {
+		return ErrInvalidBarcode
+	}
+	return nil
+}
+
+// converts a valid digitable line into its barcode
+func BarcodeOf(line string) (string, error) {
+	line = Normalize(line)
+	if err := ValidateLine(line); err != nil {
+		return "", err
+	}
+	return barcodeOf(line), nil
+}
+
+// converts a valid barcode into its digitable line
+func LineOf(barcode string) (string, error) {
+	if err := ValidateBarcode(barcode); err != nil {
+		return "", err
+	}
+	first := barcode[0:4] + barcode[19:24]
+	second := barcode[24:34]
+	third := barcode[34:44]
+	return first + string(mod10(first)) +
+		second + string(mod10(second)) +
+		third + string(mod10(third)) +
+		barcode[4:5] + barcode[5:19], nil
+}
+
+// validates the line and the barcode, and that both encode the same boleto
+func ValidateMatch(line string, barcode string) error {
+	converted, err := BarcodeOf(line)
+	if err != nil {
+		return err
+	}
+	if err := ValidateBarcode(barcode); err != nil {
+		return err
+	}
+	if converted != barcode {
+		return ErrLineMismatch
+	}
+	return nil
+}
+
+// builds a barcode in reais, computing its general check digit. The free
+// field has 25 digits defined by the issuing bank.
+func NewBarcode(bank string, dueFactor int, value int64, freeField string) (string, error) {
+	if len(bank) != 3 || !isNumeric(bank) || len(freeField) != 25 || !isNumeric(freeField) {
+		return "", ErrInvalidField
+	}
+	if dueFactor != 0 && (dueFactor < MIN_DUE_FACTOR || dueFactor > MAX_DUE_FACTOR) {
+		return "", ErrInvalidField
+	}
+	if value < 0 || value > MAX_VALUE {
+		return "", ErrInvalidField
+	}
+	body := bank + CURRENCY_REAL + leftPad(dueFactor, 4) + leftPad(value, 10) + freeField
+	return body[:4] + string(mod11(body)) + body[4:], nil
+}
+
+// reads a digitable line or a barcode, formatted or not
+func Parse(value string) (*Boleto, error) {
+	value = Normalize(value)
+	barcode := value
+	if len(value) == LINE_LENGTH {
+		converted, err := BarcodeOf(value)
+		if err != nil {
+			return nil, err
+		}
+		barcode = converted
+	}
+	line, err := LineOf(barcode)
+	if err != nil {
+		return nil, err
+	}
+	dueFactor, _ := strconv.Atoi(barcode[5:9])
+	amount, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	return &Boleto{
+		Bank:      barcode[0:3],
+		Currency:  barcode[3:4],
+		DueFactor: dueFactor,
+		Value:     amount,
+		FreeField: barcode[19:44],
+		Barcode:   barcode,
+		Line:      line,
+	}, nil
+}
+
+// formats the line for display: 00190.50095 40144.816069 06809.350314 3 37370000000100.
+// Invalid lines are returned normalized.
+func Format(line string) string {
+	line = Normalize(line)
+	if ValidateLine(line) != nil {
+		return line
+	}
+	return line[0:5] + "." + line[5:10] + " " +
+		line[10:15] + "." + line[15:21] + " " +
+		line[21:26] + "." + line[26:32] + " " +
+		line[32:33] + " " + line[33:47]
+}
+
+// returns the due factor of the day. Factors restart at 1000 on 2025-02-22.
+func DueFactor(dueDate time.Time) int {
+	days := daysSince(factorBase, dueDate)
+	if days <= MAX_DUE_FACTOR {
+		return days
+	}
+	return (days-MIN_DUE_FACTOR)%(MAX_DUE_FACTOR-MIN_DUE_FACTOR+1) + MIN_DUE_FACTOR
+}
+
+// returns the day of the due factor closest to reference, since the same
+// factor repeats every 9000 days. A zero factor has no due date.
+func DueDate(factor int, reference time.Time) time.Time {
+	if factor == 0 {
+		return time.Time{}
+	}
+	cycle := MAX_DUE_FACTOR - MIN_DUE_FACTOR + 1
+	due := factorBase.AddDate(0, 0, factor)
+	for daysSince(due, reference) > cycle/2 {
+		due = due.AddDate(0, 0, cycle)
+	}
+	return due
+}
+
+func barcodeOf(line string) string {
+	return line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31]
+}
+
+// computes a field check digit: weights 2 and 1 alternate from the right and
+// the digits of each product are added
+func mod10(digits string) byte {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		if product > 9 {
+			product -= 9
+		}
+		sum += product
+		weight = 3 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// computes the general check digit of the barcode: weights cycle from 2 to 9
+// from the right, and results 0, 10 and 11 become 1
+func mod11(digits string) byte {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	digit := 11 - sum%11
+	if digit == 0 || digit > 9 {
+		return '1'
+	}
+	return byte('0' + digit)
+}
+
+// counts calendar days, ignoring the time and zone of the instants
+func daysSince(from time.Time, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+func leftPad[T int | int64](value T, width int) string {
+	result := strconv.FormatInt(int64(value), 10)
+	return strings.Repeat("0", width-len(result)) + result
+}
+
+func isNumeric(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
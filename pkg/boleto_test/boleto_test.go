@@ -0,0 +1,89 @@
+package boleto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/boleto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	line    = "00190500954014481606906809350314337370000000100"
+	barcode = "00193373700000001000500940144816060680935031"
+)
+
+func TestBoletoShouldValidateLine(t *testing.T) {
+	require.NoError(t, boleto.ValidateLine(line))
+	require.NoError(t, boleto.ValidateLine("00190.50095 40144.816069 06809.350314 3 37370000000100"))
+	invalid := []string{
+		"00190500964014481606906809350314337370000000100", // first field digit
+		"00190500954014481606806809350314337370000000100", // second field digit
+		"00190500954014481606906809350315337370000000100", // third field digit
+		"00190500954014481606906809350314437370000000100", // general digit
+		"00190500954014481606906809350314337370000000200", // value
+		"0019050095401448160690680935031433737000000010",
+		"0019050095401448160690680935031433737000000010A",
+		"",
+	}
+	for _, value := range invalid {
+		require.ErrorIs(t, boleto.ValidateLine(value), boleto.ErrInvalidLine, "%s should be invalid", value)
+	}
+}
+
+func TestBoletoShouldValidateBarcode(t *testing.T) {
+	require.NoError(t, boleto.ValidateBarcode(barcode))
+	require.ErrorIs(t, boleto.ValidateBarcode("00194373700000001000500940144816060680935031"), boleto.ErrInvalidBarcode)
+	require.ErrorIs(t, boleto.ValidateBarcode(barcode[:43]), boleto.ErrInvalidBarcode)
+}
+
+func TestBoletoShouldConvertBetweenLineAndBarcode(t *testing.T) {
+	converted, err := boleto.BarcodeOf(line)
+	require.NoError(t, err)
+	require.Equal(t, barcode, converted)
+	converted, err = boleto.LineOf(barcode)
+	require.NoError(t, err)
+	require.Equal(t, line, converted)
+	require.NoError(t, boleto.ValidateMatch(line, barcode))
+	other, err := boleto.NewBarcode("461", 1603, 12990, "0000000000000000000006453")
+	require.NoError(t, err)
+	require.ErrorIs(t, boleto.ValidateMatch(line, other), boleto.ErrLineMismatch)
+}
+
+func TestBoletoShouldBuildAndParseBarcode(t *testing.T) {
+	built, err := boleto.NewBarcode("001", 3737, 100, "0500940144816060680935031")
+	require.NoError(t, err)
+	require.Equal(t, barcode, built)
+	parsed, err := boleto.Parse("00190.50095 40144.816069 06809.350314 3 37370000000100")
+	require.NoError(t, err)
+	require.Equal(t, &boleto.Boleto{
+		Bank:      "001",
+		Currency:  "9",
+		DueFactor: 3737,
+		Value:     100,
+		FreeField: "0500940144816060680935031",
+		Barcode:   barcode,
+		Line:      line,
+	}, parsed)
+	_, err = boleto.NewBarcode("001", 999, 100, "0500940144816060680935031")
+	require.ErrorIs(t, err, boleto.ErrInvalidField)
+	_, err = boleto.NewBarcode("01", 1000, 100, "0500940144816060680935031")
+	require.ErrorIs(t, err, boleto.ErrInvalidField)
+}
+
+func TestBoletoShouldFormatLine(t *testing.T) {
+	require.Equal(t, "00190.50095 40144.816069 06809.350314 3 37370000000100", boleto.Format(line))
+	require.Equal(t, "123", boleto.Format("1.2 3"), "invalid lines should not be masked")
+}
+
+func TestBoletoShouldComputeDueFactor(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	require.Equal(t, 1000, boleto.DueFactor(day(2000, time.July, 3)))
+	require.Equal(t, 9999, boleto.DueFactor(day(2025, time.February, 21)))
+	require.Equal(t, 1000, boleto.DueFactor(day(2025, time.February, 22)), "factors should restart after 9999")
+	require.Equal(t, day(2025, time.February, 22), boleto.DueDate(1000, day(2026, time.October, 18)))
+	require.Equal(t, day(2000, time.July, 3), boleto.DueDate(1000, day(2001, time.January, 1)))
+	require.True(t, boleto.DueDate(0, day(2026, time.October, 18)).IsZero())
+}
@@ -0,0 +1,300 @@
+// Package cassette records the HTTP interactions with the Asaas API into
+// JSON files and replays them, so integration tests can run in CI without
+// credentials or network access.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+)
+
+var (
+	ErrUnmatchedRequest = errors.New("cassette: request not recorded")
+	ErrInvalidMode      = errors.New("cassette: invalid mode")
+)
+
+type Mode int
+
+const (
+	// answers from the cassette file and never touches the network
+	MODE_REPLAY Mode = iota
+	// sends the requests and writes them to the cassette file on Stop
+	MODE_RECORD
+)
+
+// headers that are never written, whatever the redactor says
+var strippedHeaders = []string{"access_token", "Authorization", "Cookie", "Set-Cookie"}
+
+// personal data masked in cassettes besides the default redactor keys, since
+// cassette files are committed to the repository
+var personalKeys = []string{"name", "address", "addressNumber", "postalCode", "province"}
+
+type Request struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Query  string            `json:"query,omitempty"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int               `json:"statusCode"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper to be given to rest_asaas.WithTransport.
+// Recorded interactions are matched by method, path, query and body, each
+// one answering a single request, in the order they were recorded.
+type Recorder struct {
+	mutex     sync.Mutex
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	redactor  *rest_asaas.Redactor
+	cassette  *Cassette
+	used      []bool
+	unmatched []string
+}
+
+// creates a recorder for the cassette file in path. In replay mode the file
+// must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	recorder := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		redactor:  rest_asaas.NewDefaultRedactor().AddKeys(personalKeys...),
+		cassette:  &Cassette{},
+	}
+	switch mode {
+	case MODE_RECORD:
+		return recorder, nil
+	case MODE_REPLAY:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, recorder.cassette); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		recorder.used = make([]bool, len(recorder.cassette.Interactions))
+		return recorder, nil
+	}
+	return nil, ErrInvalidMode
+}
+
+// defines the transport used to reach the API in record mode
+func (r *Recorder) SetTransport(transport http.RoundTripper) *Recorder {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.transport = transport
+	return r
+}
+
+// defines the rules used to strip personal data from the cassette. It must
+// be the same when recording and replaying, since requests are matched
+// after redaction.
+func (r *Recorder) SetRedactor(redactor *rest_asaas.Redactor) *Recorder {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.redactor = redactor
+	return r
+}
+
+func (r *Recorder) GetMode() Mode {
+	return r.mode
+}
+
+// returns the interactions recorded or loaded so far
+func (r *Recorder) Interactions() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Interaction{}, r.cassette.Interactions...)
+}
+
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readBody(request)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == MODE_RECORD {
+		return r.record(request, body)
+	}
+	return r.replay(request, body)
+}
+
+// saves the cassette in record mode. In replay mode it reports the requests
+// that were not found, in case the caller swallowed the transport error.
+func (r *Recorder) Stop() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.mode == MODE_REPLAY {
+		if len(r.unmatched) > 0 {
+			return fmt.Errorf("%w: %s", ErrUnmatchedRequest, strings.Join(r.unmatched, ", "))
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(request *http.Request, body string) (*http.Response, error) {
+	r.mutex.Lock()
+	transport, redactor := r.transport, r.redactor
+	r.mutex.Unlock()
+	// a RoundTripper must not modify the request, so the body already read
+	// is sent on a copy
+	clone := request.Clone(request.Context())
+	clone.Body = io.NopCloser(strings.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(body)), nil
+	}
+	response, err := transport.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	interaction := Interaction{
+		Request: r.newRequest(request, body, redactor),
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     redactHeader(response.Header, redactor),
+			Body:       redactor.JSON(string(data)),
+		},
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return response, nil
+}
+
+func (r *Recorder) replay(request *http.Request, body string) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	wanted := r.newRequest(request, body, r.redactor)
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, wanted) {
+			continue
+		}
+		r.used[i] = true
+		return newResponse(request, interaction.Response), nil
+	}
+	description := wanted.Method + " " + wanted.Path
+	if wanted.Query != "" {
+		description += "?" + wanted.Query
+	}
+	if wanted.Body != "" {
+		description += " " + wanted.Body
+	}
+	r.unmatched = append(r.unmatched, description)
+	return nil, fmt.Errorf("%w in %s: %s", ErrUnmatchedRequest, r.path, description)
+}
+
+// builds the request as written in the cassette, without credentials or personal data
+func (r *Recorder) newRequest(request *http.Request, body string, redactor *rest_asaas.Redactor) Request {
+	return Request{
+		Method: request.Method,
+		Path:   request.URL.Path,
+		Query:  redactQuery(request.URL.Query(), redactor),
+		Header: redactHeader(request.Header, redactor),
+		Body:   redactor.JSON(body),
+	}
+}
+
+func matches(recorded Request, wanted Request) bool {
+	return recorded.Method == wanted.Method &&
+		recorded.Path == wanted.Path &&
+		recorded.Query == wanted.Query &&
+		recorded.Body == wanted.Body
+}
+
+func newResponse(request *http.Request, recorded Response) *http.Response {
+	header := http.Header{}
+	for key, value := range recorded.Header {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       request,
+	}
+}
+
+func readBody(request *http.Request) (string, error) {
+	if request.Body == nil {
+		return "", nil
+	}
+	data, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func redactHeader(header http.Header, redactor *rest_asaas.Redactor) map[string]string {
+	result := map[string]string{}
+	for key := range header {
+		result[key] = header.Get(key)
+	}
+	for _, key := range strippedHeaders {
+		for name := range result {
+			if strings.EqualFold(name, key) {
+				delete(result, name)
+			}
+		}
+	}
+	result = redactor.Headers(result)
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func redactQuery(query url.Values, redactor *rest_asaas.Redactor) string {
+	for key := range query {
+		if redactor.IsRedacted(key) {
+			masked := redactor.Map(map[string]interface{}{key: ""})
+			query.Set(key, fmt.Sprint(masked[key]))
+		}
+	}
+	return query.Encode()
+}
@@ -0,0 +1,145 @@
+package cassette_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/cassette"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func newCustomer() *model.Customer {
+	return model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+}
+
+// records the creation of a customer against the fake server and returns the cassette path
+func recordCustomer(t *testing.T) (string, *model.Customer) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cassettes", "customer.json")
+	server := asaastest.NewServer()
+	defer server.Close()
+	recorder, err := cassette.NewRecorder(path, cassette.MODE_RECORD)
+	require.NoError(t, err)
+	restEntity, err := server.Client(rest_asaas.WithTransport(recorder))
+	require.NoError(t, err)
+	created, err := restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err)
+	_, err = restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetName("John Doe"))
+	require.NoError(t, err)
+	require.NoError(t, recorder.Stop(), "should save the cassette")
+	return path, created
+}
+
+func newReplayClient(t *testing.T, path string) (*cassette.Recorder, *rest_asaas.Rest) {
+	t.Helper()
+	recorder, err := cassette.NewRecorder(path, cassette.MODE_REPLAY)
+	require.NoError(t, err)
+	restEntity, err := rest_asaas.NewClient(
+		rest_asaas.WithCredentialProvider(rest_asaas.CredentialProviderFunc(func(ctx context.Context) (*model.Credential, error) {
+			return model.NewCredential().SetAccessToken("$aact_replay").SetLink("http://asaas.invalid"), nil
+		})),
+		rest_asaas.WithTransport(recorder),
+		rest_asaas.WithRetryPolicy(rest_asaas.NoRetryPolicy()),
+	)
+	require.NoError(t, err)
+	return recorder, restEntity
+}
+
+func TestRecorderShouldStripCredentialsAndPersonalData(t *testing.T) {
+	path, _ := recordCustomer(t)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	require.NotContains(t, content, asaastest.API_KEY, "access token should not be written")
+	require.NotContains(t, strings.ToLower(content), "access_token", "access token header should be stripped")
+	require.NotContains(t, content, "00000000191", "document should be redacted")
+	require.NotContains(t, content, "31999999999", "phone should be redacted")
+	require.NotContains(t, content, "John Doe", "name should be redacted")
+	require.Contains(t, content, "/v3/customers", "other fields should be kept")
+}
+
+func TestRecorderShouldRedactTheAddress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "customer.json")
+	server := asaastest.NewServer()
+	defer server.Close()
+	recorder, err := cassette.NewRecorder(path, cassette.MODE_RECORD)
+	require.NoError(t, err)
+	restEntity, err := server.Client(rest_asaas.WithTransport(recorder))
+	require.NoError(t, err)
+	customer := newCustomer().SetAddress("Rua das Acácias", "1234", "apto 56", "Savassi", "30140071")
+	_, err = restEntity.CreateCustomer(context.Background(), customer)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Stop())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	require.NotContains(t, content, "Acácias", "address should be redacted")
+	require.NotContains(t, content, "1234", "address number should be redacted")
+	require.NotContains(t, content, "Savassi", "province should be redacted")
+	require.NotContains(t, content, "30140071", "postal code should be redacted")
+	require.Contains(t, content, "apto 56", "complement should be kept")
+}
+
+func TestRecorderShouldReplayInteractions(t *testing.T) {
+	path, created := recordCustomer(t)
+	recorder, restEntity := newReplayClient(t, path)
+	replayed, err := restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err, "recorded request should be replayed")
+	require.Equal(t, created.ID, replayed.ID)
+	require.Equal(t, rest_asaas.REDACTED, replayed.CpfCnpj)
+	customers, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetName("John Doe"))
+	require.NoError(t, err)
+	require.Len(t, customers.Data, 1)
+	require.NoError(t, recorder.Stop())
+}
+
+func TestRecorderShouldFailOnUnmatchedRequest(t *testing.T) {
+	path, _ := recordCustomer(t)
+	recorder, restEntity := newReplayClient(t, path)
+	_, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetGroupName("vip"))
+	require.ErrorIs(t, err, cassette.ErrUnmatchedRequest, "different query should not match")
+	_, err = restEntity.CreateCustomer(context.Background(), newCustomer().SetObservations("vip"))
+	require.ErrorIs(t, err, cassette.ErrUnmatchedRequest, "different body should not match")
+	_, err = restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.NoError(t, err)
+	_, err = restEntity.CreateCustomer(context.Background(), newCustomer())
+	require.ErrorIs(t, err, cassette.ErrUnmatchedRequest, "each interaction should answer a single request")
+	err = recorder.Stop()
+	require.ErrorIs(t, err, cassette.ErrUnmatchedRequest, "stop should report the unmatched requests")
+	require.Contains(t, err.Error(), "GET /v3/customers?groupName=vip")
+}
+
+func TestRecorderShouldRequireCassetteInReplayMode(t *testing.T) {
+	_, err := cassette.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), cassette.MODE_REPLAY)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = cassette.NewRecorder("any.json", cassette.Mode(42))
+	require.ErrorIs(t, err, cassette.ErrInvalidMode)
+}
+
+func TestRecorderShouldNotModifyTheRequest(t *testing.T) {
+	server := asaastest.NewServer()
+	defer server.Close()
+	recorder, err := cassette.NewRecorder(filepath.Join(t.TempDir(), "customer.json"), cassette.MODE_RECORD)
+	require.NoError(t, err)
+	body := io.NopCloser(strings.NewReader(`{"name":"John Doe","cpfCnpj":"00000000191"}`))
+	request, err := http.NewRequest(http.MethodPost, server.URL()+"/v3/customers", body)
+	require.NoError(t, err)
+	request.Header.Set("access_token", asaastest.API_KEY)
+	response, err := recorder.RoundTrip(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.True(t, body == request.Body, "the caller's request should be left untouched")
+	require.Len(t, recorder.Interactions(), 1)
+}
@@ -0,0 +1,166 @@
+// Package document validates and formats the Brazilian taxpayer documents
+// accepted by Asaas: CPF for individuals and CNPJ for companies, including
+// the alphanumeric CNPJ.
+package document
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidDocument = errors.New("invalid CPF or CNPJ")
+	ErrInvalidCPF      = errors.New("invalid CPF")
+	ErrInvalidCNPJ     = errors.New("invalid CNPJ")
+)
+
+const (
+	CPF_LENGTH  = 11
+	CNPJ_LENGTH = 14
+)
+
+type Type int
+
+const (
+	TYPE_UNKNOWN Type = iota
+	TYPE_CPF
+	TYPE_CNPJ
+)
+
+func (t Type) String() string {
+	switch t {
+	case TYPE_CPF:
+		return "CPF"
+	case TYPE_CNPJ:
+		return "CNPJ"
+	}
+	return "unknown"
+}
+
+// removes the mask and spaces, and upper-cases the letters of alphanumeric
+// CNPJs. Other characters are kept so validation can reject them.
+func Normalize(document string) string {
+	var builder strings.Builder
+	for _, char := range strings.ToUpper(document) {
+		switch char {
+		case '.', '-', '/', ' ', '\t':
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+// validates the document, returning whether it is a CPF or a CNPJ
+func Validate(document string) (Type, error) {
+	normalized := Normalize(document)
+	switch len(normalized) {
+	case CPF_LENGTH:
+		return TYPE_CPF, ValidateCPF(normalized)
+	case CNPJ_LENGTH:
+		return TYPE_CNPJ, ValidateCNPJ(normalized)
+	}
+	return TYPE_UNKNOWN, ErrInvalidDocument
+}
+
+func IsValid(document string) bool {
+	_, err := Validate(document)
+	return err == nil
+}
+
+// validates the length and check digits of a CPF, masked or not
+func ValidateCPF(cpf string) error {
+	cpf = Normalize(cpf)
+	if len(cpf) != CPF_LENGTH || !isNumeric(cpf) || isRepeated(cpf) {
+		return ErrInvalidCPF
+	}
+	if checkDigit(cpf[:9], 10) != cpf[9] || checkDigit(cpf[:10], 11) != cpf[10] {
+		return ErrInvalidCPF
+	}
+	return nil
+}
+
+// validates the length and check digits of a CNPJ, masked or not. The first
+// twelve characters may be letters since the alphanumeric CNPJ, while the
+// check digits are always numbers.
+func ValidateCNPJ(cnpj string) error {
+	cnpj = Normalize(cnpj)
+	if len(cnpj) != CNPJ_LENGTH || !isAlphanumeric(cnpj[:12]) || !isNumeric(cnpj[12:]) || isRepeated(cnpj) {
+		return ErrInvalidCNPJ
+	}
+	if cnpjCheckDigit(cnpj[:12]) != cnpj[12] || cnpjCheckDigit(cnpj[:13]) != cnpj[13] {
+		return ErrInvalidCNPJ
+	}
+	return nil
+}
+
+// formats the document for display: 000.000.001-91 or 12.ABC.345/01DE-35.
+// Invalid documents are returned normalized, without a mask.
+func Format(document string) string {
+	normalized := Normalize(document)
+	documentType, err := Validate(normalized)
+	if err != nil {
+		return normalized
+	}
+	if documentType == TYPE_CPF {
+		return normalized[:3] + "." + normalized[3:6] + "." + normalized[6:9] + "-" + normalized[9:]
+	}
+	return normalized[:2] + "." + normalized[2:5] + "." + normalized[5:8] + "/" + normalized[8:12] + "-" + normalized[12:]
+}
+
+// computes a CPF check digit with weights starting at weight and going down to 2
+func checkDigit(digits string, weight int) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * (weight - i)
+	}
+	return remainderDigit(sum)
+}
+
+// computes a CNPJ check digit with weights cycling from 9 down to 2, from the
+// right. Letters are worth their ASCII code minus 48, as defined by the
+// Receita Federal for the alphanumeric CNPJ.
+func cnpjCheckDigit(characters string) byte {
+	sum := 0
+	weight := 2
+	for i := len(characters) - 1; i >= 0; i-- {
+		sum += int(characters[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return remainderDigit(sum)
+}
+
+func remainderDigit(sum int) byte {
+	remainder := sum % 11
+	if remainder < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - remainder)
+}
+
+func isNumeric(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(value string) bool {
+	for i := 0; i < len(value); i++ {
+		char := value[i]
+		if (char < '0' || char > '9') && (char < 'A' || char > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// documents made of a single repeated digit pass the check digits but are not valid
+func isRepeated(value string) bool {
+	return strings.Count(value, value[:1]) == len(value)
+}
@@ -0,0 +1,53 @@
+package document_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/document"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentShouldNormalizeMaskedInput(t *testing.T) {
+	require.Equal(t, "00000000191", document.Normalize("000.000.001-91"))
+	require.Equal(t, "11222333000181", document.Normalize(" 11.222.333/0001-81 "))
+	require.Equal(t, "12ABC34501DE35", document.Normalize("12.abc.345/01de-35"))
+}
+
+func TestDocumentShouldValidateCPF(t *testing.T) {
+	for _, cpf := range []string{"00000000191", "000.000.001-91", "123.456.789-09", "11144477735"} {
+		require.NoError(t, document.ValidateCPF(cpf), "%s should be valid", cpf)
+	}
+	for _, cpf := range []string{"00000000192", "00000000193", "11111111111", "1234567890", "1234567890A", ""} {
+		require.ErrorIs(t, document.ValidateCPF(cpf), document.ErrInvalidCPF, "%s should be invalid", cpf)
+	}
+}
+
+func TestDocumentShouldValidateCNPJ(t *testing.T) {
+	for _, cnpj := range []string{"11.222.333/0001-81", "11222333000181", "12.ABC.345/01DE-35", "12abc34501de35"} {
+		require.NoError(t, document.ValidateCNPJ(cnpj), "%s should be valid", cnpj)
+	}
+	for _, cnpj := range []string{"11222333000182", "12ABC34501DE36", "12ABC34501DEA5", "00000000000000", "12ABC34501D#35"} {
+		require.ErrorIs(t, document.ValidateCNPJ(cnpj), document.ErrInvalidCNPJ, "%s should be invalid", cnpj)
+	}
+}
+
+func TestDocumentShouldInferType(t *testing.T) {
+	documentType, err := document.Validate("000.000.001-91")
+	require.NoError(t, err)
+	require.Equal(t, document.TYPE_CPF, documentType)
+	documentType, err = document.Validate("12.ABC.345/01DE-35")
+	require.NoError(t, err)
+	require.Equal(t, document.TYPE_CNPJ, documentType)
+	documentType, err = document.Validate("123")
+	require.ErrorIs(t, err, document.ErrInvalidDocument)
+	require.Equal(t, document.TYPE_UNKNOWN, documentType)
+	require.True(t, document.IsValid("11222333000181"))
+	require.False(t, document.IsValid("11222333000182"))
+}
+
+func TestDocumentShouldFormat(t *testing.T) {
+	require.Equal(t, "000.000.001-91", document.Format("00000000191"))
+	require.Equal(t, "11.222.333/0001-81", document.Format("11222333000181"))
+	require.Equal(t, "12.ABC.345/01DE-35", document.Format("12abc34501de35"))
+	require.Equal(t, "00000000192", document.Format("000.000.001-92"), "invalid documents should not be masked")
+}
@@ -2,9 +2,11 @@ package factory_client_asaas
 
 import "github.com/pericles-luz/go-asaas/pkg/rest_asaas"
 
-func NewClient(configPath string) (*rest_asaas.Rest, error) {
-	engine := rest_asaas.NewEngine(map[string]interface{}{"InsecureSkipVerify": true})
-	restEntity, err := rest_asaas.NewRest(engine, configPath)
+// creates a client with the credential read from the JSON file in configPath.
+// Certificates are verified unless rest_asaas.WithInsecureSkipVerify is given.
+func NewClient(configPath string, opts ...rest_asaas.Option) (*rest_asaas.Rest, error) {
+	opts = append([]rest_asaas.Option{rest_asaas.WithCredentialFile(configPath)}, opts...)
+	restEntity, err := rest_asaas.NewClient(opts...)
 	if err != nil {
 		return nil, err
 	}
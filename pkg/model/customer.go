@@ -3,22 +3,46 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"strings"
+
+	"github.com/pericles-luz/go-asaas/pkg/document"
 )
 
 var (
 	ErrNameIsRequired     = errors.New("name is required")
 	ErrDocumentIsRequired = errors.New("document is required")
 	ErrNoContactInfo      = errors.New("at least one contact info (mobile phone or email) is required")
+	ErrPersonTypeMismatch = errors.New("person type does not match the document")
 )
 
 type Customer struct {
-	ID                string `json:"id"`
-	Name              string `json:"name"`
-	CpfCnpj           string `json:"cpfCnpj"`
-	MobilePhone       string `json:"mobilePhone"`
-	Email             string `json:"email"`
-	PersonType        string `json:"personType"` // "FISICA" or "JURIDICA"
-	ExternalReference string `json:"externalReference"`
+	ID                   string     `json:"id"`
+	Name                 string     `json:"name"`
+	CpfCnpj              string     `json:"cpfCnpj"`
+	MobilePhone          string     `json:"mobilePhone"`
+	Email                string     `json:"email"`
+	PersonType           PersonType `json:"personType"`
+	ExternalReference    string     `json:"externalReference"`
+	Phone                string     `json:"phone"`
+	AdditionalEmails     string     `json:"additionalEmails"` // comma separated
+	Company              string     `json:"company"`
+	GroupName            string     `json:"groupName"`
+	Address              string     `json:"address"`
+	AddressNumber        string     `json:"addressNumber"`
+	Complement           string     `json:"complement"`
+	Province             string     `json:"province"` // neighborhood
+	PostalCode           string     `json:"postalCode"`
+	City                 int        `json:"city"` // read only, Asaas city ID derived from the postal code
+	CityName             string     `json:"cityName"`
+	State                string     `json:"state"`
+	Country              string     `json:"country"`
+	MunicipalInscription string     `json:"municipalInscription"`
+	StateInscription     string     `json:"stateInscription"`
+	Observations         string     `json:"observations"`
+	NotificationDisabled bool       `json:"notificationDisabled"`
+	ForeignCustomer      bool       `json:"foreignCustomer"`
+	DateCreated          Date       `json:"dateCreated"` // read only
+	Deleted              bool       `json:"deleted"`     // read only
 }
 
 func NewCustomer() *Customer {
@@ -30,8 +54,9 @@ func (c *Customer) SetName(name string) *Customer {
 	return c
 }
 
+// defines the document, removing its mask
 func (c *Customer) SetCpfCnpj(cpfCnpj string) *Customer {
-	c.CpfCnpj = cpfCnpj
+	c.CpfCnpj = document.Normalize(cpfCnpj)
 	return c
 }
 
@@ -45,7 +70,7 @@ func (c *Customer) SetEmail(email string) *Customer {
 	return c
 }
 
-func (c *Customer) SetPersonType(personType string) *Customer {
+func (c *Customer) SetPersonType(personType PersonType) *Customer {
 	c.PersonType = personType
 	return c
 }
@@ -55,42 +80,196 @@ func (c *Customer) SetExternalReference(externalReference string) *Customer {
 	return c
 }
 
+func (c *Customer) SetPhone(phone string) *Customer {
+	c.Phone = phone
+	return c
+}
+
+// defines the emails that also receive notifications
+func (c *Customer) SetAdditionalEmails(emails ...string) *Customer {
+	c.AdditionalEmails = strings.Join(emails, ",")
+	return c
+}
+
+// returns the additional emails as a list
+func (c *Customer) GetAdditionalEmails() []string {
+	result := []string{}
+	for _, email := range strings.Split(c.AdditionalEmails, ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			result = append(result, email)
+		}
+	}
+	return result
+}
+
+func (c *Customer) SetCompany(company string) *Customer {
+	c.Company = company
+	return c
+}
+
+func (c *Customer) SetGroupName(groupName string) *Customer {
+	c.GroupName = groupName
+	return c
+}
+
+// defines the address used to register boletos. City and state are derived
+// by Asaas from the postal code.
+func (c *Customer) SetAddress(address string, addressNumber string, complement string, province string, postalCode string) *Customer {
+	c.Address = address
+	c.AddressNumber = addressNumber
+	c.Complement = complement
+	c.Province = province
+	return c.SetPostalCode(postalCode)
+}
+
+func (c *Customer) SetAddressNumber(addressNumber string) *Customer {
+	c.AddressNumber = addressNumber
+	return c
+}
+
+func (c *Customer) SetComplement(complement string) *Customer {
+	c.Complement = complement
+	return c
+}
+
+func (c *Customer) SetProvince(province string) *Customer {
+	c.Province = province
+	return c
+}
+
+// defines the postal code, removing its mask
+func (c *Customer) SetPostalCode(postalCode string) *Customer {
+	c.PostalCode = strings.NewReplacer("-", "", ".", "", " ", "").Replace(postalCode)
+	return c
+}
+
+func (c *Customer) SetMunicipalInscription(municipalInscription string) *Customer {
+	c.MunicipalInscription = municipalInscription
+	return c
+}
+
+func (c *Customer) SetStateInscription(stateInscription string) *Customer {
+	c.StateInscription = stateInscription
+	return c
+}
+
+func (c *Customer) SetObservations(observations string) *Customer {
+	c.Observations = observations
+	return c
+}
+
+func (c *Customer) SetNotificationDisabled(notificationDisabled bool) *Customer {
+	c.NotificationDisabled = notificationDisabled
+	return c
+}
+
+func (c *Customer) SetForeignCustomer(foreignCustomer bool) *Customer {
+	c.ForeignCustomer = foreignCustomer
+	return c
+}
+
+// tells whether the customer has the address required to register boletos
+func (c *Customer) HasAddress() bool {
+	return c.Address != "" && c.AddressNumber != "" && c.PostalCode != ""
+}
+
+// validates the customer before it is sent, checking the document digits
+// and that the person type matches it
 func (c *Customer) Validate() error {
+	if err := c.validateRequired(); err != nil {
+		return err
+	}
+	personType, err := PersonTypeOf(c.CpfCnpj)
+	if err != nil {
+		return NewFieldError("cpfCnpj", err)
+	}
+	if c.PersonType == "" {
+		return nil
+	}
+	if !c.PersonType.Valid() {
+		return NewFieldError("personType", ErrInvalidPersonType)
+	}
+	if c.PersonType != personType {
+		return NewFieldError("personType", ErrPersonTypeMismatch)
+	}
+	return nil
+}
+
+func (c *Customer) validateRequired() error {
 	if c.Name == "" {
-		return ErrNameIsRequired
+		return NewFieldError("name", ErrNameIsRequired)
 	}
 	if c.CpfCnpj == "" {
-		return ErrDocumentIsRequired
+		return NewFieldError("cpfCnpj", ErrDocumentIsRequired)
 	}
 	if c.MobilePhone == "" && c.Email == "" {
-		return ErrNoContactInfo
+		return NewFieldError("mobilePhone", ErrNoContactInfo)
 	}
 	return nil
 }
 
+// returns the document formatted for display
+func (c *Customer) FormattedCpfCnpj() string {
+	return document.Format(c.CpfCnpj)
+}
+
+// returns the fields accepted by Asaas when creating a customer. Empty
+// optional fields are left out; read only fields are never sent.
 func (c *Customer) ToMap() map[string]interface{} {
 	result := map[string]interface{}{
-		"name":    c.Name,
-		"cpfCnpj": c.CpfCnpj,
+		"name":                 c.Name,
+		"cpfCnpj":              c.CpfCnpj,
+		"notificationDisabled": c.NotificationDisabled,
+		"foreignCustomer":      c.ForeignCustomer,
 	}
-	if c.MobilePhone != "" {
-		result["mobilePhone"] = c.MobilePhone
+	optional := map[string]string{
+		"mobilePhone":          c.MobilePhone,
+		"email":                c.Email,
+		"personType":           string(c.PersonType),
+		"externalReference":    c.ExternalReference,
+		"phone":                c.Phone,
+		"additionalEmails":     c.AdditionalEmails,
+		"company":              c.Company,
+		"groupName":            c.GroupName,
+		"address":              c.Address,
+		"addressNumber":        c.AddressNumber,
+		"complement":           c.Complement,
+		"province":             c.Province,
+		"postalCode":           c.PostalCode,
+		"municipalInscription": c.MunicipalInscription,
+		"stateInscription":     c.StateInscription,
+		"observations":         c.Observations,
 	}
-	if c.Email != "" {
-		result["email"] = c.Email
-	}
-	if c.PersonType != "" {
-		result["personType"] = c.PersonType
-	}
-	if c.ExternalReference != "" {
-		result["externalReference"] = c.ExternalReference
+	for key, value := range optional {
+		if value != "" {
+			result[key] = value
+		}
 	}
 	return result
 }
 
+// decodes a customer returned by Asaas. The document was already validated
+// by the API, so only the required fields are checked.
+// decodes a customer returned by Asaas. Only the ID is checked: Asaas
+// requires no contact info, so customers without phone or email exist.
 func (c *Customer) Unmarshal(raw []byte) error {
 	if err := json.Unmarshal(raw, c); err != nil {
 		return err
 	}
-	return c.Validate()
+	if c.ID == "" {
+		return NewFieldError("id", ErrCustomerIDIsRequired)
+	}
+	return nil
+}
+
+// infers the person type from a CPF or CNPJ
+func PersonTypeOf(cpfCnpj string) (PersonType, error) {
+	documentType, err := document.Validate(cpfCnpj)
+	if err != nil {
+		return "", err
+	}
+	if documentType == document.TYPE_CNPJ {
+		return PERSON_TYPE_JURIDICA, nil
+	}
+	return PERSON_TYPE_FISICA, nil
 }
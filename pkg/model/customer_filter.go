@@ -0,0 +1,115 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/pericles-luz/go-asaas/pkg/document"
+)
+
+var (
+	ErrInvalidLimit  = errors.New("limit must be between 1 and 100")
+	ErrInvalidOffset = errors.New("offset must not be negative")
+)
+
+// CustomerFilter selects the customers listed by Asaas. Empty fields don't
+// filter, and a zero limit uses the Asaas default.
+type CustomerFilter struct {
+	Name              string
+	Email             string
+	CpfCnpj           string
+	GroupName         string
+	ExternalReference string
+	Offset            int
+	Limit             int
+}
+
+func NewCustomerFilter() *CustomerFilter {
+	return &CustomerFilter{}
+}
+
+func (f *CustomerFilter) SetName(name string) *CustomerFilter {
+	f.Name = name
+	return f
+}
+
+func (f *CustomerFilter) SetEmail(email string) *CustomerFilter {
+	f.Email = email
+	return f
+}
+
+// filters by document, removing its mask
+func (f *CustomerFilter) SetCpfCnpj(cpfCnpj string) *CustomerFilter {
+	f.CpfCnpj = document.Normalize(cpfCnpj)
+	return f
+}
+
+func (f *CustomerFilter) SetGroupName(groupName string) *CustomerFilter {
+	f.GroupName = groupName
+	return f
+}
+
+func (f *CustomerFilter) SetExternalReference(externalReference string) *CustomerFilter {
+	f.ExternalReference = externalReference
+	return f
+}
+
+func (f *CustomerFilter) SetOffset(offset int) *CustomerFilter {
+	f.Offset = offset
+	return f
+}
+
+func (f *CustomerFilter) SetLimit(limit int) *CustomerFilter {
+	f.Limit = limit
+	return f
+}
+
+// returns a copy of the filter starting at offset
+func (f *CustomerFilter) WithOffset(offset int) *CustomerFilter {
+	result := *f
+	result.Offset = offset
+	return &result
+}
+
+func (f *CustomerFilter) Validate() error {
+	return validatePage(f.Offset, f.Limit)
+}
+
+// returns the query parameters documented by Asaas
+func (f *CustomerFilter) ToMap() map[string]interface{} {
+	result := pageQuery(f.Offset, f.Limit)
+	optional := map[string]string{
+		"name":              f.Name,
+		"email":             f.Email,
+		"cpfCnpj":           document.Normalize(f.CpfCnpj),
+		"groupName":         f.GroupName,
+		"externalReference": f.ExternalReference,
+	}
+	for key, value := range optional {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func validatePage(offset int, limit int) error {
+	if offset < 0 {
+		return ErrInvalidOffset
+	}
+	if limit < 0 || limit > MAX_LIST_LIMIT {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// returns the offset and limit parameters, leaving out the defaults
+func pageQuery(offset int, limit int) map[string]interface{} {
+	result := map[string]interface{}{}
+	if offset > 0 {
+		result["offset"] = offset
+	}
+	if limit > 0 {
+		result["limit"] = limit
+	}
+	return result
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pericles-luz/go-asaas/pkg/document"
+)
+
+var ErrNothingToUpdate = errors.New("nothing to update")
+
+// CustomerUpdate is a partial update of a customer. Only the fields defined
+// through its setters are sent, so a field can be cleared by setting it
+// empty and a flag can be turned off by setting it false.
+type CustomerUpdate struct {
+	fields map[string]interface{}
+}
+
+func NewCustomerUpdate() *CustomerUpdate {
+	return &CustomerUpdate{fields: map[string]interface{}{}}
+}
+
+func (u *CustomerUpdate) set(field string, value interface{}) *CustomerUpdate {
+	if u.fields == nil {
+		u.fields = map[string]interface{}{}
+	}
+	u.fields[field] = value
+	return u
+}
+
+func (u *CustomerUpdate) SetName(name string) *CustomerUpdate {
+	return u.set("name", name)
+}
+
+// defines the document, removing its mask
+func (u *CustomerUpdate) SetCpfCnpj(cpfCnpj string) *CustomerUpdate {
+	return u.set("cpfCnpj", document.Normalize(cpfCnpj))
+}
+
+func (u *CustomerUpdate) SetMobilePhone(mobilePhone string) *CustomerUpdate {
+	return u.set("mobilePhone", mobilePhone)
+}
+
+func (u *CustomerUpdate) SetEmail(email string) *CustomerUpdate {
+	return u.set("email", email)
+}
+
+func (u *CustomerUpdate) SetPersonType(personType PersonType) *CustomerUpdate {
+	return u.set("personType", personType)
+}
+
+func (u *CustomerUpdate) SetExternalReference(externalReference string) *CustomerUpdate {
+	return u.set("externalReference", externalReference)
+}
+
+func (u *CustomerUpdate) SetPhone(phone string) *CustomerUpdate {
+	return u.set("phone", phone)
+}
+
+func (u *CustomerUpdate) SetAdditionalEmails(emails ...string) *CustomerUpdate {
+	return u.set("additionalEmails", strings.Join(emails, ","))
+}
+
+func (u *CustomerUpdate) SetCompany(company string) *CustomerUpdate {
+	return u.set("company", company)
+}
+
+func (u *CustomerUpdate) SetGroupName(groupName string) *CustomerUpdate {
+	return u.set("groupName", groupName)
+}
+
+func (u *CustomerUpdate) SetAddress(address string, addressNumber string, complement string, province string, postalCode string) *CustomerUpdate {
+	u.set("address", address)
+	u.set("addressNumber", addressNumber)
+	u.set("complement", complement)
+	u.set("province", province)
+	return u.SetPostalCode(postalCode)
+}
+
+func (u *CustomerUpdate) SetAddressNumber(addressNumber string) *CustomerUpdate {
+	return u.set("addressNumber", addressNumber)
+}
+
+func (u *CustomerUpdate) SetComplement(complement string) *CustomerUpdate {
+	return u.set("complement", complement)
+}
+
+func (u *CustomerUpdate) SetProvince(province string) *CustomerUpdate {
+	return u.set("province", province)
+}
+
+// defines the postal code, removing its mask
+func (u *CustomerUpdate) SetPostalCode(postalCode string) *CustomerUpdate {
+	return u.set("postalCode", strings.NewReplacer("-", "", ".", "", " ", "").Replace(postalCode))
+}
+
+func (u *CustomerUpdate) SetMunicipalInscription(municipalInscription string) *CustomerUpdate {
+	return u.set("municipalInscription", municipalInscription)
+}
+
+func (u *CustomerUpdate) SetStateInscription(stateInscription string) *CustomerUpdate {
+	return u.set("stateInscription", stateInscription)
+}
+
+func (u *CustomerUpdate) SetObservations(observations string) *CustomerUpdate {
+	return u.set("observations", observations)
+}
+
+func (u *CustomerUpdate) SetNotificationDisabled(notificationDisabled bool) *CustomerUpdate {
+	return u.set("notificationDisabled", notificationDisabled)
+}
+
+func (u *CustomerUpdate) SetForeignCustomer(foreignCustomer bool) *CustomerUpdate {
+	return u.set("foreignCustomer", foreignCustomer)
+}
+
+// tells whether the field will be sent
+func (u *CustomerUpdate) Has(field string) bool {
+	_, ok := u.fields[field]
+	return ok
+}
+
+// validates only the fields being changed: name and document can't be
+// cleared, a new document must be valid and match the person type when both
+// change, and the contact info can't be cleared altogether
+func (u *CustomerUpdate) Validate() error {
+	if u == nil || len(u.fields) == 0 {
+		return ErrNothingToUpdate
+	}
+	if u.Has("name") && u.fields["name"] == "" {
+		return NewFieldError("name", ErrNameIsRequired)
+	}
+	if u.Has("mobilePhone") && u.Has("email") && u.fields["mobilePhone"] == "" && u.fields["email"] == "" {
+		return NewFieldError("mobilePhone", ErrNoContactInfo)
+	}
+	personType, _ := u.fields["personType"].(PersonType)
+	if u.Has("personType") && !personType.Valid() {
+		return NewFieldError("personType", ErrInvalidPersonType)
+	}
+	if !u.Has("cpfCnpj") {
+		return nil
+	}
+	cpfCnpj := u.fields["cpfCnpj"].(string)
+	if cpfCnpj == "" {
+		return NewFieldError("cpfCnpj", ErrDocumentIsRequired)
+	}
+	documentPersonType, err := PersonTypeOf(cpfCnpj)
+	if err != nil {
+		return NewFieldError("cpfCnpj", err)
+	}
+	if u.Has("personType") && personType != documentPersonType {
+		return NewFieldError("personType", ErrPersonTypeMismatch)
+	}
+	return nil
+}
+
+// returns a copy of the fields being changed
+func (u *CustomerUpdate) ToMap() map[string]interface{} {
+	result := make(map[string]interface{}, len(u.fields))
+	for key, value := range u.fields {
+		if personType, ok := value.(PersonType); ok {
+			value = string(personType)
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,233 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+	_ "time/tzdata"
+)
+
+var (
+	ErrInvalidDate     = errors.New("invalid date")
+	ErrInvalidDateTime = errors.New("invalid date time")
+)
+
+const (
+	DATE_LAYOUT     = "2006-01-02"
+	DATETIME_LAYOUT = "2006-01-02 15:04:05"
+	TIMEZONE        = "America/Sao_Paulo"
+)
+
+// Asaas dates and timestamps are always in Brasília time. The zone database
+// is embedded, so this works in containers without tzdata.
+var location = mustLoadLocation(TIMEZONE)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// returns the location used to interpret Asaas dates
+func Location() *time.Location {
+	return location
+}
+
+// Date is a calendar day in São Paulo, marshalled as "2006-01-02". The zero
+// value is no date and is marshalled as null.
+type Date struct {
+	time time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time: time.Date(year, month, day, 0, 0, 0, 0, location)}
+}
+
+// returns the day of t in São Paulo
+func DateOf(t time.Time) Date {
+	t = t.In(location)
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+// returns the current day in São Paulo
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.ParseInLocation(DATE_LAYOUT, value, location)
+	if err != nil {
+		return Date{}, ErrInvalidDate
+	}
+	return Date{time: parsed}, nil
+}
+
+// returns midnight of the day in São Paulo
+func (d Date) Time() time.Time {
+	return d.time
+}
+
+func (d Date) IsZero() bool {
+	return d.time.IsZero()
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.time.Format(DATE_LAYOUT)
+}
+
+func (d Date) AddDays(days int) Date {
+	return d.AddDate(0, 0, days)
+}
+
+// adds years, months and days, normalizing overflows like time.AddDate
+func (d Date) AddDate(years int, months int, days int) Date {
+	return DateOf(d.time.AddDate(years, months, days))
+}
+
+// returns the number of days from d to other, negative when other is earlier
+func (d Date) DaysUntil(other Date) int {
+	from := time.Date(d.time.Year(), d.time.Month(), d.time.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(other.time.Year(), other.time.Month(), other.time.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func (d Date) Before(other Date) bool {
+	return d.time.Before(other.time)
+}
+
+func (d Date) After(other Date) bool {
+	return d.time.After(other.time)
+}
+
+func (d Date) Equal(other Date) bool {
+	return d.time.Equal(other.time)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// reads "2006-01-02". Null and empty strings are read as no date.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value, err := unquote(data)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	if value == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// DateTime is an instant reported by Asaas as "2006-01-02 15:04:05" in São
+// Paulo time. The zero value is marshalled as null.
+type DateTime struct {
+	time time.Time
+}
+
+func NewDateTime(t time.Time) DateTime {
+	if t.IsZero() {
+		return DateTime{}
+	}
+	return DateTime{time: t.In(location)}
+}
+
+// parses "2006-01-02 15:04:05" in São Paulo time. RFC 3339 timestamps and
+// plain dates are accepted as well.
+func ParseDateTime(value string) (DateTime, error) {
+	for _, layout := range []string{DATETIME_LAYOUT, time.RFC3339Nano, DATE_LAYOUT} {
+		parsed, err := time.ParseInLocation(layout, value, location)
+		if err == nil {
+			return NewDateTime(parsed), nil
+		}
+	}
+	return DateTime{}, ErrInvalidDateTime
+}
+
+func (d DateTime) Time() time.Time {
+	return d.time
+}
+
+func (d DateTime) IsZero() bool {
+	return d.time.IsZero()
+}
+
+// returns the day of the instant in São Paulo
+func (d DateTime) Date() Date {
+	if d.IsZero() {
+		return Date{}
+	}
+	return DateOf(d.time)
+}
+
+func (d DateTime) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.time.Format(DATETIME_LAYOUT)
+}
+
+func (d DateTime) Before(other DateTime) bool {
+	return d.time.Before(other.time)
+}
+
+func (d DateTime) After(other DateTime) bool {
+	return d.time.After(other.time)
+}
+
+func (d DateTime) Equal(other DateTime) bool {
+	return d.time.Equal(other.time)
+}
+
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// reads the Asaas timestamp. Null and empty strings are read as no time.
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	value, err := unquote(data)
+	if err != nil {
+		return ErrInvalidDateTime
+	}
+	if value == "" {
+		*d = DateTime{}
+		return nil
+	}
+	parsed, err := ParseDateTime(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// returns the JSON string, or an empty string for null
+func unquote(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
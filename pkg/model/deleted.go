@@ -0,0 +1,17 @@
+package model
+
+import "encoding/json"
+
+// Deleted is the answer of Asaas to DELETE requests
+type Deleted struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func NewDeleted() *Deleted {
+	return &Deleted{}
+}
+
+func (d *Deleted) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, d)
+}
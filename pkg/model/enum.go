@@ -0,0 +1,291 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrInvalidEnum          = errors.New("invalid enum value")
+	ErrInvalidBillingType   = errors.New("invalid billing type")
+	ErrInvalidCycle         = errors.New("invalid cycle")
+	ErrInvalidPersonType    = errors.New("invalid person type")
+	ErrInvalidPaymentStatus = errors.New("invalid payment status")
+	ErrInvalidWebhookEvent  = errors.New("invalid webhook event")
+)
+
+type BillingType string
+
+const (
+	BILLING_TYPE_BOLETO      BillingType = "BOLETO"
+	BILLING_TYPE_CREDIT_CARD BillingType = "CREDIT_CARD"
+	BILLING_TYPE_PIX         BillingType = "PIX"
+	BILLING_TYPE_UNDEFINED   BillingType = "UNDEFINED"
+)
+
+type Cycle string
+
+const (
+	CYCLE_WEEKLY       Cycle = "WEEKLY"
+	CYCLE_BIWEEKLY     Cycle = "BIWEEKLY"
+	CYCLE_MONTHLY      Cycle = "MONTHLY"
+	CYCLE_BIMONTHLY    Cycle = "BIMONTHLY"
+	CYCLE_QUARTERLY    Cycle = "QUARTERLY"
+	CYCLE_SEMIANNUALLY Cycle = "SEMIANNUALLY"
+	CYCLE_YEARLY       Cycle = "YEARLY"
+)
+
+type PersonType string
+
+const (
+	PERSON_TYPE_FISICA   PersonType = "FISICA"
+	PERSON_TYPE_JURIDICA PersonType = "JURIDICA"
+)
+
+type PaymentStatus string
+
+const (
+	PAYMENT_STATUS_PENDING                      PaymentStatus = "PENDING"
+	PAYMENT_STATUS_RECEIVED                     PaymentStatus = "RECEIVED"
+	PAYMENT_STATUS_CONFIRMED                    PaymentStatus = "CONFIRMED"
+	PAYMENT_STATUS_OVERDUE                      PaymentStatus = "OVERDUE"
+	PAYMENT_STATUS_REFUNDED                     PaymentStatus = "REFUNDED"
+	PAYMENT_STATUS_RECEIVED_IN_CASH             PaymentStatus = "RECEIVED_IN_CASH"
+	PAYMENT_STATUS_REFUND_REQUESTED             PaymentStatus = "REFUND_REQUESTED"
+	PAYMENT_STATUS_REFUND_IN_PROGRESS           PaymentStatus = "REFUND_IN_PROGRESS"
+	PAYMENT_STATUS_CHARGEBACK_REQUESTED         PaymentStatus = "CHARGEBACK_REQUESTED"
+	PAYMENT_STATUS_CHARGEBACK_DISPUTE           PaymentStatus = "CHARGEBACK_DISPUTE"
+	PAYMENT_STATUS_AWAITING_CHARGEBACK_REVERSAL PaymentStatus = "AWAITING_CHARGEBACK_REVERSAL"
+	PAYMENT_STATUS_DUNNING_REQUESTED            PaymentStatus = "DUNNING_REQUESTED"
+	PAYMENT_STATUS_DUNNING_RECEIVED             PaymentStatus = "DUNNING_RECEIVED"
+	PAYMENT_STATUS_AWAITING_RISK_ANALYSIS       PaymentStatus = "AWAITING_RISK_ANALYSIS"
+)
+
+type WebhookEvent string
+
+const (
+	EVENT_PAYMENT_CREATED                         WebhookEvent = "PAYMENT_CREATED"
+	EVENT_PAYMENT_AWAITING_RISK_ANALYSIS          WebhookEvent = "PAYMENT_AWAITING_RISK_ANALYSIS"
+	EVENT_PAYMENT_APPROVED_BY_RISK_ANALYSIS       WebhookEvent = "PAYMENT_APPROVED_BY_RISK_ANALYSIS"
+	EVENT_PAYMENT_REPROVED_BY_RISK_ANALYSIS       WebhookEvent = "PAYMENT_REPROVED_BY_RISK_ANALYSIS"
+	EVENT_PAYMENT_AUTHORIZED                      WebhookEvent = "PAYMENT_AUTHORIZED"
+	EVENT_PAYMENT_UPDATED                         WebhookEvent = "PAYMENT_UPDATED"
+	EVENT_PAYMENT_CONFIRMED                       WebhookEvent = "PAYMENT_CONFIRMED"
+	EVENT_PAYMENT_RECEIVED                        WebhookEvent = "PAYMENT_RECEIVED"
+	EVENT_PAYMENT_CREDIT_CARD_CAPTURE_REFUSED     WebhookEvent = "PAYMENT_CREDIT_CARD_CAPTURE_REFUSED"
+	EVENT_PAYMENT_ANTICIPATED                     WebhookEvent = "PAYMENT_ANTICIPATED"
+	EVENT_PAYMENT_OVERDUE                         WebhookEvent = "PAYMENT_OVERDUE"
+	EVENT_PAYMENT_DELETED                         WebhookEvent = "PAYMENT_DELETED"
+	EVENT_PAYMENT_RESTORED                        WebhookEvent = "PAYMENT_RESTORED"
+	EVENT_PAYMENT_REFUNDED                        WebhookEvent = "PAYMENT_REFUNDED"
+	EVENT_PAYMENT_PARTIALLY_REFUNDED              WebhookEvent = "PAYMENT_PARTIALLY_REFUNDED"
+	EVENT_PAYMENT_REFUND_IN_PROGRESS              WebhookEvent = "PAYMENT_REFUND_IN_PROGRESS"
+	EVENT_PAYMENT_RECEIVED_IN_CASH_UNDONE         WebhookEvent = "PAYMENT_RECEIVED_IN_CASH_UNDONE"
+	EVENT_PAYMENT_CHARGEBACK_REQUESTED            WebhookEvent = "PAYMENT_CHARGEBACK_REQUESTED"
+	EVENT_PAYMENT_CHARGEBACK_DISPUTE              WebhookEvent = "PAYMENT_CHARGEBACK_DISPUTE"
+	EVENT_PAYMENT_AWAITING_CHARGEBACK_REVERSAL    WebhookEvent = "PAYMENT_AWAITING_CHARGEBACK_REVERSAL"
+	EVENT_PAYMENT_DUNNING_RECEIVED                WebhookEvent = "PAYMENT_DUNNING_RECEIVED"
+	EVENT_PAYMENT_DUNNING_REQUESTED               WebhookEvent = "PAYMENT_DUNNING_REQUESTED"
+	EVENT_PAYMENT_BANK_SLIP_VIEWED                WebhookEvent = "PAYMENT_BANK_SLIP_VIEWED"
+	EVENT_PAYMENT_CHECKOUT_VIEWED                 WebhookEvent = "PAYMENT_CHECKOUT_VIEWED"
+	EVENT_PAYMENT_SPLIT_CANCELLED                 WebhookEvent = "PAYMENT_SPLIT_CANCELLED"
+	EVENT_PAYMENT_SPLIT_DIVERGENCE_BLOCK          WebhookEvent = "PAYMENT_SPLIT_DIVERGENCE_BLOCK"
+	EVENT_PAYMENT_SPLIT_DIVERGENCE_BLOCK_FINISHED WebhookEvent = "PAYMENT_SPLIT_DIVERGENCE_BLOCK_FINISHED"
+
+	EVENT_SUBSCRIPTION_CREATED                         WebhookEvent = "SUBSCRIPTION_CREATED"
+	EVENT_SUBSCRIPTION_UPDATED                         WebhookEvent = "SUBSCRIPTION_UPDATED"
+	EVENT_SUBSCRIPTION_INACTIVATED                     WebhookEvent = "SUBSCRIPTION_INACTIVATED"
+	EVENT_SUBSCRIPTION_DELETED                         WebhookEvent = "SUBSCRIPTION_DELETED"
+	EVENT_SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK          WebhookEvent = "SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK"
+	EVENT_SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK_FINISHED WebhookEvent = "SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK_FINISHED"
+)
+
+var (
+	billingTypes = enumSet(BILLING_TYPE_BOLETO, BILLING_TYPE_CREDIT_CARD, BILLING_TYPE_PIX, BILLING_TYPE_UNDEFINED)
+	cycles       = enumSet(CYCLE_WEEKLY, CYCLE_BIWEEKLY, CYCLE_MONTHLY, CYCLE_BIMONTHLY, CYCLE_QUARTERLY, CYCLE_SEMIANNUALLY, CYCLE_YEARLY)
+	personTypes  = enumSet(PERSON_TYPE_FISICA, PERSON_TYPE_JURIDICA)
+
+	paymentStatuses = enumSet(
+		PAYMENT_STATUS_PENDING,
+		PAYMENT_STATUS_RECEIVED,
+		PAYMENT_STATUS_CONFIRMED,
+		PAYMENT_STATUS_OVERDUE,
+		PAYMENT_STATUS_REFUNDED,
+		PAYMENT_STATUS_RECEIVED_IN_CASH,
+		PAYMENT_STATUS_REFUND_REQUESTED,
+		PAYMENT_STATUS_REFUND_IN_PROGRESS,
+		PAYMENT_STATUS_CHARGEBACK_REQUESTED,
+		PAYMENT_STATUS_CHARGEBACK_DISPUTE,
+		PAYMENT_STATUS_AWAITING_CHARGEBACK_REVERSAL,
+		PAYMENT_STATUS_DUNNING_REQUESTED,
+		PAYMENT_STATUS_DUNNING_RECEIVED,
+		PAYMENT_STATUS_AWAITING_RISK_ANALYSIS,
+	)
+
+	webhookEvents = enumSet(
+		EVENT_PAYMENT_CREATED,
+		EVENT_PAYMENT_AWAITING_RISK_ANALYSIS,
+		EVENT_PAYMENT_APPROVED_BY_RISK_ANALYSIS,
+		EVENT_PAYMENT_REPROVED_BY_RISK_ANALYSIS,
+		EVENT_PAYMENT_AUTHORIZED,
+		EVENT_PAYMENT_UPDATED,
+		EVENT_PAYMENT_CONFIRMED,
+		EVENT_PAYMENT_RECEIVED,
+		EVENT_PAYMENT_CREDIT_CARD_CAPTURE_REFUSED,
+		EVENT_PAYMENT_ANTICIPATED,
+		EVENT_PAYMENT_OVERDUE,
+		EVENT_PAYMENT_DELETED,
+		EVENT_PAYMENT_RESTORED,
+		EVENT_PAYMENT_REFUNDED,
+		EVENT_PAYMENT_PARTIALLY_REFUNDED,
+		EVENT_PAYMENT_REFUND_IN_PROGRESS,
+		EVENT_PAYMENT_RECEIVED_IN_CASH_UNDONE,
+		EVENT_PAYMENT_CHARGEBACK_REQUESTED,
+		EVENT_PAYMENT_CHARGEBACK_DISPUTE,
+		EVENT_PAYMENT_AWAITING_CHARGEBACK_REVERSAL,
+		EVENT_PAYMENT_DUNNING_RECEIVED,
+		EVENT_PAYMENT_DUNNING_REQUESTED,
+		EVENT_PAYMENT_BANK_SLIP_VIEWED,
+		EVENT_PAYMENT_CHECKOUT_VIEWED,
+		EVENT_PAYMENT_SPLIT_CANCELLED,
+		EVENT_PAYMENT_SPLIT_DIVERGENCE_BLOCK,
+		EVENT_PAYMENT_SPLIT_DIVERGENCE_BLOCK_FINISHED,
+		EVENT_SUBSCRIPTION_CREATED,
+		EVENT_SUBSCRIPTION_UPDATED,
+		EVENT_SUBSCRIPTION_INACTIVATED,
+		EVENT_SUBSCRIPTION_DELETED,
+		EVENT_SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK,
+		EVENT_SUBSCRIPTION_SPLIT_DIVERGENCE_BLOCK_FINISHED,
+	)
+)
+
+func (b BillingType) Valid() bool {
+	return billingTypes[b]
+}
+
+func (b BillingType) Validate() error {
+	return checkEnum(b, ErrInvalidBillingType)
+}
+
+func (c Cycle) Valid() bool {
+	return cycles[c]
+}
+
+func (c Cycle) Validate() error {
+	return checkEnum(c, ErrInvalidCycle)
+}
+
+func (p PersonType) Valid() bool {
+	return personTypes[p]
+}
+
+func (p PersonType) Validate() error {
+	return checkEnum(p, ErrInvalidPersonType)
+}
+
+func (p PaymentStatus) Valid() bool {
+	return paymentStatuses[p]
+}
+
+func (p PaymentStatus) Validate() error {
+	return checkEnum(p, ErrInvalidPaymentStatus)
+}
+
+func (w WebhookEvent) Valid() bool {
+	return webhookEvents[w]
+}
+
+func (w WebhookEvent) Validate() error {
+	return checkEnum(w, ErrInvalidWebhookEvent)
+}
+
+type enum interface {
+	~string
+	Valid() bool
+}
+
+func enumSet[E enum](values ...E) map[E]bool {
+	result := make(map[E]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
+
+// empty values are always accepted, since Asaas omits optional enums
+func checkEnum[E enum](value E, invalid error) error {
+	if value == "" || value.Valid() {
+		return nil
+	}
+	return fmt.Errorf("%w: %w %q", invalid, ErrInvalidEnum, string(value))
+}
+
+// decodes data into v, then fails when any enum in v has a value unknown to
+// this package. Models with an Unmarshal method, such as a webhook.WebhookPayment,
+// are decoded with it so their own checks still run; other values use
+// json.Unmarshal. Plain decoding accepts unknown values, so values added by
+// Asaas don't break existing clients. Enums are not checked on marshalling
+// either: call Validate on the model or the enum before sending it.
+func DecodeStrict(data []byte, v interface{}) error {
+	if err := decode(data, v); err != nil {
+		return err
+	}
+	return validateEnums(reflect.ValueOf(v))
+}
+
+func decode(data []byte, v interface{}) error {
+	if decoder, ok := v.(interface{ Unmarshal(data []byte) error }); ok {
+		return decoder.Unmarshal(data)
+	}
+	return json.Unmarshal(data, v)
+}
+
+type enumValidator interface {
+	Valid() bool
+	Validate() error
+}
+
+var enumValidatorType = reflect.TypeOf((*enumValidator)(nil)).Elem()
+
+// walks pointers, interfaces, structs, slices and maps looking for enums
+func validateEnums(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return validateEnums(value.Elem())
+	case reflect.String:
+		if value.Type().Implements(enumValidatorType) {
+			return value.Interface().(enumValidator).Validate()
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !value.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := validateEnums(value.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := validateEnums(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iterator := value.MapRange()
+		for iterator.Next() {
+			if err := validateEnums(iterator.Key()); err != nil {
+				return err
+			}
+			if err := validateEnums(iterator.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
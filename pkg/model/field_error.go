@@ -0,0 +1,20 @@
+package model
+
+// FieldError tells which field of a model failed validation. It unwraps to
+// the cause, so callers can still match the sentinel with errors.Is.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func NewFieldError(field string, err error) *FieldError {
+	return &FieldError{Field: field, Err: err}
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/pericles-luz/go-asaas/pkg/boleto"
+)
+
+// PaymentIdentificationField is the digitable line (linha digitável) and the
+// barcode of a boleto payment
+type PaymentIdentificationField struct {
+	IdentificationField string `json:"identificationField"`
+	NossoNumero         string `json:"nossoNumero"`
+	BarCode             string `json:"barCode"`
+}
+
+func NewPaymentIdentificationField() *PaymentIdentificationField {
+	return &PaymentIdentificationField{}
+}
+
+// checks the FEBRABAN check digits of the line and the barcode, and that
+// both encode the same boleto
+func (f *PaymentIdentificationField) Validate() error {
+	return boleto.ValidateMatch(f.IdentificationField, f.BarCode)
+}
+
+// returns the line formatted for display
+func (f *PaymentIdentificationField) FormattedIdentificationField() string {
+	return boleto.Format(f.IdentificationField)
+}
+
+// returns the value encoded in the barcode
+func (f *PaymentIdentificationField) Value() Money {
+	parsed, err := boleto.Parse(f.BarCode)
+	if err != nil {
+		return Money{}
+	}
+	return Cents(parsed.Value)
+}
+
+// decodes the answer of Asaas, refusing lines that fail the local checks so
+// a corrupted line is never shown to the payer
+func (f *PaymentIdentificationField) Unmarshal(raw []byte) error {
+	if err := json.Unmarshal(raw, f); err != nil {
+		return err
+	}
+	f.IdentificationField = boleto.Normalize(f.IdentificationField)
+	return f.Validate()
+}
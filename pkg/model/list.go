@@ -0,0 +1,39 @@
+package model
+
+import "encoding/json"
+
+const (
+	DEFAULT_LIST_LIMIT = 10
+	MAX_LIST_LIMIT     = 100
+)
+
+// List is a page of any Asaas list endpoint
+type List[T any] struct {
+	HasMore    bool `json:"hasMore"`
+	TotalCount int  `json:"totalCount"`
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+	Data       []T  `json:"data"`
+}
+
+func NewList[T any]() *List[T] {
+	return &List[T]{
+		HasMore:    false,
+		TotalCount: 0,
+		Limit:      DEFAULT_LIST_LIMIT,
+		Offset:     0,
+		Data:       []T{},
+	}
+}
+
+func (l *List[T]) Unmarshal(data []byte) error {
+	if err := json.Unmarshal(data, l); err != nil {
+		return err
+	}
+	return nil
+}
+
+// returns the offset of the page after this one
+func (l *List[T]) NextOffset() int {
+	return l.Offset + len(l.Data)
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMoney = errors.New("invalid money value")
+
+// Money is an amount in BRL, stored as integer centavos so values like 19.99
+// never go through a float. The zero value is R$ 0,00.
+type Money struct {
+	cents int64
+}
+
+// creates an amount from centavos
+func Cents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// creates an amount from whole reais
+func Reais(reais int64) Money {
+	return Money{cents: reais * 100}
+}
+
+// parses a decimal such as "19.99", "-3.5" or "1e2", as sent by Asaas.
+// Fractions of centavos are rounded half away from zero.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Money{}, ErrInvalidMoney
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Money{}, ErrInvalidMoney
+	}
+	rat.Mul(rat, big.NewRat(100, 1))
+	quotient, remainder := new(big.Int).QuoRem(rat.Num(), rat.Denom(), new(big.Int))
+	if new(big.Int).Mul(remainder.Abs(remainder), big.NewInt(2)).Cmp(rat.Denom()) >= 0 {
+		if rat.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return Money{}, ErrInvalidMoney
+	}
+	return Money{cents: quotient.Int64()}, nil
+}
+
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{cents: m.cents + other.cents}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{cents: m.cents - other.cents}
+}
+
+func (m Money) Mul(factor int64) Money {
+	return Money{cents: m.cents * factor}
+}
+
+func (m Money) Neg() Money {
+	return Money{cents: -m.cents}
+}
+
+// returns -1, 0 or 1 when the amount is less than, equal to or greater than other
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.cents < other.cents:
+		return -1
+	case m.cents > other.cents:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.cents == other.cents
+}
+
+func (m Money) LessThan(other Money) bool {
+	return m.cents < other.cents
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.cents > other.cents
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.cents > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.cents < 0
+}
+
+// returns the decimal used by the API, such as "19.99"
+func (m Money) String() string {
+	sign, reais, cents := m.parts()
+	return sign + strconv.FormatUint(reais, 10) + "." + cents
+}
+
+// formats the amount as BRL, such as "R$ 1.234,56"
+func (m Money) Format() string {
+	sign, reais, cents := m.parts()
+	digits := strconv.FormatUint(reais, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + "R$ " + grouped.String() + "," + cents
+}
+
+func (m Money) parts() (string, uint64, string) {
+	sign := ""
+	value := uint64(m.cents)
+	if m.cents < 0 {
+		sign = "-"
+		value = -value
+	}
+	cents := strconv.FormatUint(value%100, 10)
+	if len(cents) == 1 {
+		cents = "0" + cents
+	}
+	return sign, value / 100, cents
+}
+
+// writes the amount as a JSON decimal number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// reads JSON numbers and numeric strings exactly. Null is read as zero.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return ErrInvalidMoney
+	}
+	parsed, err := ParseMoney(number.String())
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,303 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrNotificationIDIsRequired = errors.New("notification ID is required")
+	ErrInvalidNotificationEvent = errors.New("invalid notification event")
+	ErrInvalidChannel           = errors.New("invalid notification channel")
+	ErrInvalidScheduleOffset    = errors.New("invalid schedule offset")
+	ErrNoNotifications          = errors.New("no notifications to update")
+	ErrDuplicateTemplate        = errors.New("duplicate notification template")
+)
+
+// NotificationEvent is the moment a customer notification is sent
+type NotificationEvent string
+
+const (
+	NOTIFICATION_PAYMENT_CREATED         NotificationEvent = "PAYMENT_CREATED"
+	NOTIFICATION_PAYMENT_UPDATED         NotificationEvent = "PAYMENT_UPDATED"
+	NOTIFICATION_PAYMENT_RECEIVED        NotificationEvent = "PAYMENT_RECEIVED"
+	NOTIFICATION_PAYMENT_OVERDUE         NotificationEvent = "PAYMENT_OVERDUE"
+	NOTIFICATION_PAYMENT_DUEDATE_WARNING NotificationEvent = "PAYMENT_DUEDATE_WARNING"
+	NOTIFICATION_SEND_LINHA_DIGITAVEL    NotificationEvent = "SEND_LINHA_DIGITAVEL"
+)
+
+// NotificationChannel is the way a notification reaches its recipient
+type NotificationChannel string
+
+const (
+	CHANNEL_EMAIL    NotificationChannel = "EMAIL"
+	CHANNEL_SMS      NotificationChannel = "SMS"
+	CHANNEL_WHATSAPP NotificationChannel = "WHATSAPP"
+	CHANNEL_VOICE    NotificationChannel = "VOICE"
+)
+
+var (
+	notificationEvents = enumSet(
+		NOTIFICATION_PAYMENT_CREATED,
+		NOTIFICATION_PAYMENT_UPDATED,
+		NOTIFICATION_PAYMENT_RECEIVED,
+		NOTIFICATION_PAYMENT_OVERDUE,
+		NOTIFICATION_PAYMENT_DUEDATE_WARNING,
+		NOTIFICATION_SEND_LINHA_DIGITAVEL,
+	)
+	notificationChannels = enumSet(CHANNEL_EMAIL, CHANNEL_SMS, CHANNEL_WHATSAPP, CHANNEL_VOICE)
+
+	// days before the due date for warnings, or after it for overdue notices
+	scheduleOffsets = []int{0, 1, 5, 7, 10, 15, 30}
+)
+
+func (e NotificationEvent) Valid() bool {
+	return notificationEvents[e]
+}
+
+func (e NotificationEvent) Validate() error {
+	return checkEnum(e, ErrInvalidNotificationEvent)
+}
+
+func (c NotificationChannel) Valid() bool {
+	return notificationChannels[c]
+}
+
+func (c NotificationChannel) Validate() error {
+	return checkEnum(c, ErrInvalidChannel)
+}
+
+// Notification is the setting of one event for one customer. Asaas creates
+// one per event with every new customer; they can only be changed.
+type Notification struct {
+	ID                          string            `json:"id"`
+	CustomerID                  string            `json:"customer"`
+	Event                       NotificationEvent `json:"event"`
+	Enabled                     bool              `json:"enabled"`
+	EmailEnabledForProvider     bool              `json:"emailEnabledForProvider"`
+	SmsEnabledForProvider       bool              `json:"smsEnabledForProvider"`
+	EmailEnabledForCustomer     bool              `json:"emailEnabledForCustomer"`
+	SmsEnabledForCustomer       bool              `json:"smsEnabledForCustomer"`
+	PhoneCallEnabledForCustomer bool              `json:"phoneCallEnabledForCustomer"`
+	WhatsappEnabledForCustomer  bool              `json:"whatsappEnabledForCustomer"`
+	ScheduleOffset              int               `json:"scheduleOffset"`
+	Deleted                     bool              `json:"deleted"`
+}
+
+type NotificationList = List[Notification]
+
+func NewNotification() *Notification {
+	return &Notification{}
+}
+
+func NewNotificationList() *NotificationList {
+	return NewList[Notification]()
+}
+
+func (n *Notification) SetID(id string) *Notification {
+	n.ID = id
+	return n
+}
+
+func (n *Notification) SetEnabled(enabled bool) *Notification {
+	n.Enabled = enabled
+	return n
+}
+
+// turns a channel on or off for the customer
+func (n *Notification) SetChannel(channel NotificationChannel, enabled bool) *Notification {
+	switch channel {
+	case CHANNEL_EMAIL:
+		n.EmailEnabledForCustomer = enabled
+	case CHANNEL_SMS:
+		n.SmsEnabledForCustomer = enabled
+	case CHANNEL_WHATSAPP:
+		n.WhatsappEnabledForCustomer = enabled
+	case CHANNEL_VOICE:
+		n.PhoneCallEnabledForCustomer = enabled
+	}
+	return n
+}
+
+// turns a copy to the account owner on or off. Asaas only sends them by email and SMS.
+func (n *Notification) SetProviderChannel(channel NotificationChannel, enabled bool) *Notification {
+	switch channel {
+	case CHANNEL_EMAIL:
+		n.EmailEnabledForProvider = enabled
+	case CHANNEL_SMS:
+		n.SmsEnabledForProvider = enabled
+	}
+	return n
+}
+
+// returns the channels enabled for the customer
+func (n *Notification) Channels() []NotificationChannel {
+	result := []NotificationChannel{}
+	flags := []struct {
+		channel NotificationChannel
+		enabled bool
+	}{
+		{CHANNEL_EMAIL, n.EmailEnabledForCustomer},
+		{CHANNEL_SMS, n.SmsEnabledForCustomer},
+		{CHANNEL_WHATSAPP, n.WhatsappEnabledForCustomer},
+		{CHANNEL_VOICE, n.PhoneCallEnabledForCustomer},
+	}
+	for _, flag := range flags {
+		if flag.enabled {
+			result = append(result, flag.channel)
+		}
+	}
+	return result
+}
+
+func (n *Notification) SetScheduleOffset(days int) *Notification {
+	n.ScheduleOffset = days
+	return n
+}
+
+func (n *Notification) Validate() error {
+	if n.ID == "" {
+		return ErrNotificationIDIsRequired
+	}
+	if n.Event != "" && !n.Event.Valid() {
+		return NewFieldError("event", ErrInvalidNotificationEvent)
+	}
+	if !slices.Contains(scheduleOffsets, n.ScheduleOffset) {
+		return NewFieldError("scheduleOffset", ErrInvalidScheduleOffset)
+	}
+	return nil
+}
+
+// returns the fields Asaas accepts when updating a notification
+func (n *Notification) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":                     n.Enabled,
+		"emailEnabledForProvider":     n.EmailEnabledForProvider,
+		"smsEnabledForProvider":       n.SmsEnabledForProvider,
+		"emailEnabledForCustomer":     n.EmailEnabledForCustomer,
+		"smsEnabledForCustomer":       n.SmsEnabledForCustomer,
+		"phoneCallEnabledForCustomer": n.PhoneCallEnabledForCustomer,
+		"whatsappEnabledForCustomer":  n.WhatsappEnabledForCustomer,
+		"scheduleOffset":              n.ScheduleOffset,
+	}
+}
+
+func (n *Notification) Unmarshal(raw []byte) error {
+	return json.Unmarshal(raw, n)
+}
+
+// NotificationBatch updates several notifications of a customer at once
+type NotificationBatch struct {
+	CustomerID    string         `json:"customer"`
+	Notifications []Notification `json:"notifications"`
+}
+
+func NewNotificationBatch(customerID string) *NotificationBatch {
+	return &NotificationBatch{CustomerID: customerID, Notifications: []Notification{}}
+}
+
+func (b *NotificationBatch) Add(notifications ...Notification) *NotificationBatch {
+	b.Notifications = append(b.Notifications, notifications...)
+	return b
+}
+
+func (b *NotificationBatch) Validate() error {
+	if b.CustomerID == "" {
+		return ErrCustomerIDIsRequired
+	}
+	if len(b.Notifications) == 0 {
+		return ErrNoNotifications
+	}
+	for i := range b.Notifications {
+		if err := b.Notifications[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *NotificationBatch) ToMap() map[string]interface{} {
+	notifications := make([]map[string]interface{}, 0, len(b.Notifications))
+	for i := range b.Notifications {
+		notification := b.Notifications[i].ToMap()
+		notification["id"] = b.Notifications[i].ID
+		notifications = append(notifications, notification)
+	}
+	return map[string]interface{}{
+		"customer":      b.CustomerID,
+		"notifications": notifications,
+	}
+}
+
+func (b *NotificationBatch) Unmarshal(raw []byte) error {
+	return json.Unmarshal(raw, b)
+}
+
+// NotificationTemplate is the setting wanted for one notification. Asaas
+// keeps several notifications for some events, such as warnings 10 days
+// before and on the due date, so the event and the schedule offset together
+// pick the notification it changes.
+type NotificationTemplate struct {
+	Event            NotificationEvent
+	Enabled          bool
+	Channels         []NotificationChannel
+	ProviderChannels []NotificationChannel
+	ScheduleOffset   int
+}
+
+// NotificationProfile is a set of templates applied to the notifications of
+// a customer, such as the standard setup of enterprise customers
+type NotificationProfile struct {
+	Templates []NotificationTemplate
+}
+
+func NewNotificationProfile(templates ...NotificationTemplate) *NotificationProfile {
+	return &NotificationProfile{Templates: templates}
+}
+
+func (p *NotificationProfile) Validate() error {
+	for i, template := range p.Templates {
+		if !template.Event.Valid() {
+			return NewFieldError("event", ErrInvalidNotificationEvent)
+		}
+		for _, channel := range append(slices.Clone(template.Channels), template.ProviderChannels...) {
+			if !channel.Valid() {
+				return NewFieldError("channels", ErrInvalidChannel)
+			}
+		}
+		if !slices.Contains(scheduleOffsets, template.ScheduleOffset) {
+			return NewFieldError("scheduleOffset", ErrInvalidScheduleOffset)
+		}
+		if slices.ContainsFunc(p.Templates[:i], template.matches) {
+			return NewFieldError("event", ErrDuplicateTemplate)
+		}
+	}
+	return nil
+}
+
+// tells whether both templates change the same notification
+func (t NotificationTemplate) matches(other NotificationTemplate) bool {
+	return t.Event == other.Event && t.ScheduleOffset == other.ScheduleOffset
+}
+
+// returns the notifications changed by the profile. Channels missing from a
+// template are turned off; notifications without a template are left out.
+func (p *NotificationProfile) Apply(notifications []Notification) []Notification {
+	result := []Notification{}
+	for _, notification := range notifications {
+		for _, template := range p.Templates {
+			if template.Event != notification.Event || template.ScheduleOffset != notification.ScheduleOffset {
+				continue
+			}
+			notification.SetEnabled(template.Enabled)
+			for _, channel := range []NotificationChannel{CHANNEL_EMAIL, CHANNEL_SMS, CHANNEL_WHATSAPP, CHANNEL_VOICE} {
+				notification.SetChannel(channel, slices.Contains(template.Channels, channel))
+				notification.SetProviderChannel(channel, slices.Contains(template.ProviderChannels, channel))
+			}
+			result = append(result, notification)
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrPaymentIDIsRequired = errors.New("payment ID is required")
+	ErrDueDateIsRequired   = errors.New("due date is required")
+)
+
+// Payment is a charge (cobrança) of a customer, either one-off or generated
+// by a subscription
+type Payment struct {
+	ID                    string        `json:"id"`
+	CustomerID            string        `json:"customer"`
+	SubscriptionID        string        `json:"subscription"` // read only, set when generated by a subscription
+	BillingType           BillingType   `json:"billingType"`
+	Value                 Money         `json:"value"`
+	NetValue              Money         `json:"netValue"` // read only, value less the Asaas fees
+	DueDate               Date          `json:"dueDate"`
+	OriginalDueDate       Date          `json:"originalDueDate"` // read only
+	Description           string        `json:"description"`
+	ExternalReference     string        `json:"externalReference"`
+	PostalService         bool          `json:"postalService"`
+	Status                PaymentStatus `json:"status"`            // read only
+	PaymentDate           Date          `json:"paymentDate"`       // read only
+	ClientPaymentDate     Date          `json:"clientPaymentDate"` // read only
+	ConfirmedDate         Date          `json:"confirmedDate"`     // read only
+	NossoNumero           string        `json:"nossoNumero"`       // read only
+	InvoiceURL            string        `json:"invoiceUrl"`        // read only
+	BankSlipURL           string        `json:"bankSlipUrl"`       // read only
+	TransactionReceiptURL string        `json:"transactionReceiptUrl"`
+	InvoiceNumber         string        `json:"invoiceNumber"` // read only
+	DateCreated           Date          `json:"dateCreated"`   // read only
+	Deleted               bool          `json:"deleted"`       // read only
+}
+
+type PaymentList = List[Payment]
+
+func NewPayment() *Payment {
+	return &Payment{}
+}
+
+func NewPaymentList() *PaymentList {
+	return NewList[Payment]()
+}
+
+func (p *Payment) SetCustomerID(customerID string) *Payment {
+	p.CustomerID = customerID
+	return p
+}
+
+func (p *Payment) SetBillingType(billingType BillingType) *Payment {
+	p.BillingType = billingType
+	return p
+}
+
+func (p *Payment) SetValue(value Money) *Payment {
+	p.Value = value
+	return p
+}
+
+func (p *Payment) SetDueDate(dueDate Date) *Payment {
+	p.DueDate = dueDate
+	return p
+}
+
+func (p *Payment) SetDescription(description string) *Payment {
+	p.Description = description
+	return p
+}
+
+func (p *Payment) SetExternalReference(externalReference string) *Payment {
+	p.ExternalReference = externalReference
+	return p
+}
+
+// asks Asaas to mail the printed boleto to the customer address
+func (p *Payment) SetPostalService(postalService bool) *Payment {
+	p.PostalService = postalService
+	return p
+}
+
+func (p *Payment) Validate() error {
+	if err := p.validateRequired(); err != nil {
+		return err
+	}
+	if !p.BillingType.Valid() {
+		return NewFieldError("billingType", ErrInvalidBillingType)
+	}
+	if !p.Value.IsPositive() {
+		return NewFieldError("value", ErrValueMustBePositive)
+	}
+	return nil
+}
+
+func (p *Payment) validateRequired() error {
+	if p.CustomerID == "" {
+		return NewFieldError("customer", ErrCustomerIDIsRequired)
+	}
+	if p.BillingType == "" {
+		return NewFieldError("billingType", ErrBillingTypeIsRequired)
+	}
+	if p.DueDate.IsZero() {
+		return NewFieldError("dueDate", ErrDueDateIsRequired)
+	}
+	return nil
+}
+
+func (p *Payment) IsBoleto() bool {
+	return p.BillingType == BILLING_TYPE_BOLETO
+}
+
+func (p *Payment) IsPix() bool {
+	return p.BillingType == BILLING_TYPE_PIX
+}
+
+// tells whether the customer already paid, in cash or through Asaas
+func (p *Payment) IsPaid() bool {
+	switch p.Status {
+	case PAYMENT_STATUS_RECEIVED, PAYMENT_STATUS_CONFIRMED, PAYMENT_STATUS_RECEIVED_IN_CASH:
+		return true
+	}
+	return false
+}
+
+// returns the fields accepted by Asaas when creating a payment. Empty
+// optional fields are left out; read only fields are never sent.
+func (p *Payment) ToMap() map[string]interface{} {
+	result := map[string]interface{}{
+		"customer":      p.CustomerID,
+		"billingType":   p.BillingType,
+		"value":         p.Value,
+		"dueDate":       p.DueDate,
+		"postalService": p.PostalService,
+	}
+	optional := map[string]string{
+		"description":       p.Description,
+		"externalReference": p.ExternalReference,
+	}
+	for key, value := range optional {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// decodes a payment returned by Asaas, checking only the required fields
+// since old payments may no longer pass the creation rules
+func (p *Payment) Unmarshal(raw []byte) error {
+	if err := json.Unmarshal(raw, p); err != nil {
+		return err
+	}
+	return p.validateRequired()
+}
@@ -0,0 +1,153 @@
+package model
+
+import "errors"
+
+var ErrInvalidDateRange = errors.New("start date must not be after end date")
+
+// DateRange limits a date filter. A zero bound leaves that side open.
+type DateRange struct {
+	From Date
+	To   Date
+}
+
+func (r DateRange) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && r.From.After(r.To) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// adds the bounds to query as field[ge] and field[le]
+func (r DateRange) addTo(query map[string]interface{}, field string) {
+	if !r.From.IsZero() {
+		query[field+"[ge]"] = r.From.String()
+	}
+	if !r.To.IsZero() {
+		query[field+"[le]"] = r.To.String()
+	}
+}
+
+// PaymentFilter selects the payments listed by Asaas. Empty fields don't
+// filter, and a zero limit uses the Asaas default.
+type PaymentFilter struct {
+	CustomerID        string
+	SubscriptionID    string
+	Status            PaymentStatus
+	BillingType       BillingType
+	ExternalReference string
+	DueDate           DateRange
+	PaymentDate       DateRange
+	DateCreated       DateRange
+	Offset            int
+	Limit             int
+}
+
+func NewPaymentFilter() *PaymentFilter {
+	return &PaymentFilter{}
+}
+
+func (f *PaymentFilter) SetCustomerID(customerID string) *PaymentFilter {
+	f.CustomerID = customerID
+	return f
+}
+
+func (f *PaymentFilter) SetSubscriptionID(subscriptionID string) *PaymentFilter {
+	f.SubscriptionID = subscriptionID
+	return f
+}
+
+func (f *PaymentFilter) SetStatus(status PaymentStatus) *PaymentFilter {
+	f.Status = status
+	return f
+}
+
+func (f *PaymentFilter) SetBillingType(billingType BillingType) *PaymentFilter {
+	f.BillingType = billingType
+	return f
+}
+
+func (f *PaymentFilter) SetExternalReference(externalReference string) *PaymentFilter {
+	f.ExternalReference = externalReference
+	return f
+}
+
+// filters by due date, from and to included. A zero date leaves that side open.
+func (f *PaymentFilter) SetDueDate(from Date, to Date) *PaymentFilter {
+	f.DueDate = DateRange{From: from, To: to}
+	return f
+}
+
+// filters by the date the payment was received
+func (f *PaymentFilter) SetPaymentDate(from Date, to Date) *PaymentFilter {
+	f.PaymentDate = DateRange{From: from, To: to}
+	return f
+}
+
+func (f *PaymentFilter) SetDateCreated(from Date, to Date) *PaymentFilter {
+	f.DateCreated = DateRange{From: from, To: to}
+	return f
+}
+
+func (f *PaymentFilter) SetOffset(offset int) *PaymentFilter {
+	f.Offset = offset
+	return f
+}
+
+func (f *PaymentFilter) SetLimit(limit int) *PaymentFilter {
+	f.Limit = limit
+	return f
+}
+
+// returns a copy of the filter starting at offset
+func (f *PaymentFilter) WithOffset(offset int) *PaymentFilter {
+	result := *f
+	result.Offset = offset
+	return &result
+}
+
+func (f *PaymentFilter) Validate() error {
+	if err := validatePage(f.Offset, f.Limit); err != nil {
+		return err
+	}
+	if f.Status != "" && !f.Status.Valid() {
+		return NewFieldError("status", ErrInvalidPaymentStatus)
+	}
+	if f.BillingType != "" && !f.BillingType.Valid() {
+		return NewFieldError("billingType", ErrInvalidBillingType)
+	}
+	ranges := []struct {
+		field string
+		value DateRange
+	}{
+		{"dueDate", f.DueDate},
+		{"paymentDate", f.PaymentDate},
+		{"dateCreated", f.DateCreated},
+	}
+	for _, item := range ranges {
+		if err := item.value.Validate(); err != nil {
+			return NewFieldError(item.field, err)
+		}
+	}
+	return nil
+}
+
+// returns the query parameters documented by Asaas
+func (f *PaymentFilter) ToMap() map[string]interface{} {
+	result := pageQuery(f.Offset, f.Limit)
+	optional := map[string]string{
+		"customer":          f.CustomerID,
+		"subscription":      f.SubscriptionID,
+		"status":            string(f.Status),
+		"billingType":       string(f.BillingType),
+		"externalReference": f.ExternalReference,
+	}
+	for key, value := range optional {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	f.DueDate.addTo(result, "dueDate")
+	f.PaymentDate.addTo(result, "paymentDate")
+	f.DateCreated.addTo(result, "dateCreated")
+	return result
+}
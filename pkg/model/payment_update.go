@@ -0,0 +1,75 @@
+package model
+
+// PaymentUpdate is a partial update of a payment. Only the fields defined
+// through its setters are sent. Asaas refuses changes to paid payments.
+type PaymentUpdate struct {
+	fields map[string]interface{}
+}
+
+func NewPaymentUpdate() *PaymentUpdate {
+	return &PaymentUpdate{fields: map[string]interface{}{}}
+}
+
+func (u *PaymentUpdate) set(field string, value interface{}) *PaymentUpdate {
+	if u.fields == nil {
+		u.fields = map[string]interface{}{}
+	}
+	u.fields[field] = value
+	return u
+}
+
+func (u *PaymentUpdate) SetBillingType(billingType BillingType) *PaymentUpdate {
+	return u.set("billingType", billingType)
+}
+
+func (u *PaymentUpdate) SetValue(value Money) *PaymentUpdate {
+	return u.set("value", value)
+}
+
+func (u *PaymentUpdate) SetDueDate(dueDate Date) *PaymentUpdate {
+	return u.set("dueDate", dueDate)
+}
+
+func (u *PaymentUpdate) SetDescription(description string) *PaymentUpdate {
+	return u.set("description", description)
+}
+
+func (u *PaymentUpdate) SetExternalReference(externalReference string) *PaymentUpdate {
+	return u.set("externalReference", externalReference)
+}
+
+func (u *PaymentUpdate) SetPostalService(postalService bool) *PaymentUpdate {
+	return u.set("postalService", postalService)
+}
+
+// tells whether the field will be sent
+func (u *PaymentUpdate) Has(field string) bool {
+	_, ok := u.fields[field]
+	return ok
+}
+
+// validates only the fields being changed
+func (u *PaymentUpdate) Validate() error {
+	if u == nil || len(u.fields) == 0 {
+		return ErrNothingToUpdate
+	}
+	if billingType, ok := u.fields["billingType"].(BillingType); ok && !billingType.Valid() {
+		return NewFieldError("billingType", ErrInvalidBillingType)
+	}
+	if value, ok := u.fields["value"].(Money); ok && !value.IsPositive() {
+		return NewFieldError("value", ErrValueMustBePositive)
+	}
+	if dueDate, ok := u.fields["dueDate"].(Date); ok && dueDate.IsZero() {
+		return NewFieldError("dueDate", ErrDueDateIsRequired)
+	}
+	return nil
+}
+
+// returns a copy of the fields being changed
+func (u *PaymentUpdate) ToMap() map[string]interface{} {
+	result := make(map[string]interface{}, len(u.fields))
+	for key, value := range u.fields {
+		result[key] = value
+	}
+	return result
+}
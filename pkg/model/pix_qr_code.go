@@ -0,0 +1,76 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+
+	"github.com/pericles-luz/go-asaas/pkg/pix"
+)
+
+var ErrInvalidQrCodeImage = errors.New("invalid pix qr code image")
+
+// PixQrCode is the QR code of a PIX payment. The image and the payload are
+// decoded and checked when it is read from Asaas.
+type PixQrCode struct {
+	EncodedImage   string   `json:"encodedImage"` // base64 PNG
+	Payload        string   `json:"payload"`      // copy-and-paste code
+	ExpirationDate DateTime `json:"expirationDate"`
+
+	PNG     []byte       `json:"-"`
+	Image   image.Image  `json:"-"`
+	Details *pix.Payload `json:"-"`
+}
+
+func NewPixQrCode() *PixQrCode {
+	return &PixQrCode{}
+}
+
+// decodes the image and parses the payload, checking its CRC
+func (q *PixQrCode) Decode() error {
+	raw, err := base64.StdEncoding.DecodeString(q.EncodedImage)
+	if err != nil {
+		return ErrInvalidQrCodeImage
+	}
+	decoded, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return ErrInvalidQrCodeImage
+	}
+	details, err := pix.ParsePayload(q.Payload)
+	if err != nil {
+		return err
+	}
+	q.PNG = raw
+	q.Image = decoded
+	q.Details = details
+	return nil
+}
+
+// returns the amount encoded in the payload, zero when the payer chooses it
+func (q *PixQrCode) Value() Money {
+	if q.Details == nil || q.Details.Amount == "" {
+		return Money{}
+	}
+	value, err := ParseMoney(q.Details.Amount)
+	if err != nil {
+		return Money{}
+	}
+	return value
+}
+
+// tells whether the code can no longer be paid at the given instant
+func (q *PixQrCode) IsExpired(at DateTime) bool {
+	return !q.ExpirationDate.IsZero() && at.After(q.ExpirationDate)
+}
+
+// decodes the answer of Asaas, refusing corrupted codes so they are never
+// shown to the payer
+func (q *PixQrCode) Unmarshal(raw []byte) error {
+	if err := json.Unmarshal(raw, q); err != nil {
+		return err
+	}
+	return q.Decode()
+}
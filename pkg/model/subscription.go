@@ -3,7 +3,6 @@ package model
 import (
 	"encoding/json"
 	"errors"
-	"time"
 )
 
 var (
@@ -16,20 +15,14 @@ var (
 	ErrOnlyBoletoAllowed     = errors.New("only boleto billing type is allowed for subscriptions")
 )
 
-const (
-	BILLING_TYPE_BOLETO = "BOLETO"
-	CYCLE_MONTHLY       = "MONTHLY"
-)
-
 type Subscription struct {
-	ID          string    `json:"id"`
-	CustomerID  string    `json:"customer"`
-	BillingType string    `json:"billingType"`
-	NextDue     string    `json:"nextDueDate"`
-	NextDueDate time.Time `json:"-"`
-	Value       float64   `json:"value"`
-	Cycle       string    `json:"cycle"`
-	Description string    `json:"description"`
+	ID          string      `json:"id"`
+	CustomerID  string      `json:"customer"`
+	BillingType BillingType `json:"billingType"`
+	NextDueDate Date        `json:"nextDueDate"`
+	Value       Money       `json:"value"`
+	Cycle       Cycle       `json:"cycle"`
+	Description string      `json:"description"`
 }
 
 func NewSubscription() *Subscription {
@@ -46,25 +39,22 @@ func (s *Subscription) SetCustomerID(customerID string) *Subscription {
 	return s
 }
 
-func (s *Subscription) SetBillingType(billingType string) *Subscription {
+func (s *Subscription) SetBillingType(billingType BillingType) *Subscription {
 	s.BillingType = billingType
 	return s
 }
 
-func (s *Subscription) SetNextDueDate(nextDueDate string) *Subscription {
-	parsedDate, err := time.Parse("2006-01-02", nextDueDate)
-	if err == nil {
-		s.NextDueDate = parsedDate
-	}
+func (s *Subscription) SetNextDueDate(nextDueDate Date) *Subscription {
+	s.NextDueDate = nextDueDate
 	return s
 }
 
-func (s *Subscription) SetValue(value float64) *Subscription {
+func (s *Subscription) SetValue(value Money) *Subscription {
 	s.Value = value
 	return s
 }
 
-func (s *Subscription) SetCycle(cycle string) *Subscription {
+func (s *Subscription) SetCycle(cycle Cycle) *Subscription {
 	s.Cycle = cycle
 	return s
 }
@@ -75,6 +65,22 @@ func (s *Subscription) SetDescription(description string) *Subscription {
 }
 
 func (s *Subscription) Validate() error {
+	if err := s.validateRequired(); err != nil {
+		return err
+	}
+	if !s.Value.IsPositive() {
+		return ErrValueMustBePositive
+	}
+	if !s.Cycle.Valid() {
+		return ErrInvalidCycle
+	}
+	if !s.IsBoleto() {
+		return ErrOnlyBoletoAllowed
+	}
+	return nil
+}
+
+func (s *Subscription) validateRequired() error {
 	if s.CustomerID == "" {
 		return ErrCustomerIDIsRequired
 	}
@@ -84,15 +90,9 @@ func (s *Subscription) Validate() error {
 	if s.NextDueDate.IsZero() {
 		return ErrNextDueDateIsRequired
 	}
-	if s.Value <= 0 {
-		return ErrValueMustBePositive
-	}
 	if s.Cycle == "" {
 		return ErrCycleIsRequired
 	}
-	if !s.IsBoleto() {
-		return ErrOnlyBoletoAllowed
-	}
 	return nil
 }
 
@@ -101,7 +101,7 @@ func (s *Subscription) ToMap() map[string]interface{} {
 		"id":          s.ID,
 		"customer":    s.CustomerID,
 		"billingType": s.BillingType,
-		"nextDueDate": s.NextDueDate.Format("2006-01-02"),
+		"nextDueDate": s.NextDueDate,
 		"value":       s.Value,
 		"cycle":       s.Cycle,
 		"description": s.Description,
@@ -109,17 +109,15 @@ func (s *Subscription) ToMap() map[string]interface{} {
 	return result
 }
 
+// decodes a subscription returned by Asaas. Only the required fields are
+// checked, so billing types and cycles refused on creation are still read.
 func (s *Subscription) Unmarshal(raw []byte) error {
 	if err := json.Unmarshal(raw, s); err != nil {
 		return err
 	}
-	s.SetNextDueDate(s.NextDue)
-	if s.NextDueDate.IsZero() {
-		return ErrNextDueDateIsRequired
-	}
-	return s.Validate()
+	return s.validateRequired()
 }
 
 func (s *Subscription) IsBoleto() bool {
-	return s.BillingType == "BOLETO"
+	return s.BillingType == BILLING_TYPE_BOLETO
 }
@@ -0,0 +1,44 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerFilterShouldEncodeAsaasParameters(t *testing.T) {
+	filter := model.NewCustomerFilter().
+		SetName("John Doe").
+		SetEmail("john@example.com").
+		SetCpfCnpj("000.000.001-91").
+		SetGroupName("Atacado").
+		SetExternalReference("12987382").
+		SetOffset(20).
+		SetLimit(100)
+	require.NoError(t, filter.Validate())
+	require.Equal(t, map[string]interface{}{
+		"name":              "John Doe",
+		"email":             "john@example.com",
+		"cpfCnpj":           "00000000191",
+		"groupName":         "Atacado",
+		"externalReference": "12987382",
+		"offset":            20,
+		"limit":             100,
+	}, filter.ToMap())
+	require.Empty(t, model.NewCustomerFilter().ToMap(), "empty filter should send no parameters")
+}
+
+func TestCustomerFilterShouldValidateBounds(t *testing.T) {
+	require.ErrorIs(t, model.NewCustomerFilter().SetLimit(101).Validate(), model.ErrInvalidLimit)
+	require.ErrorIs(t, model.NewCustomerFilter().SetLimit(-1).Validate(), model.ErrInvalidLimit)
+	require.ErrorIs(t, model.NewCustomerFilter().SetOffset(-1).Validate(), model.ErrInvalidOffset)
+}
+
+func TestCustomerFilterShouldCopyWithOffset(t *testing.T) {
+	filter := model.NewCustomerFilter().SetName("John Doe")
+	next := filter.WithOffset(10)
+	require.Equal(t, 10, next.Offset)
+	require.Equal(t, "John Doe", next.Name)
+	require.Equal(t, 0, filter.Offset, "original filter should not change")
+}
@@ -2,7 +2,9 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/pericles-luz/go-asaas/pkg/document"
 	"github.com/pericles-luz/go-asaas/pkg/model"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +20,7 @@ func TestCustomerShouldValidateWithMobilePhone(t *testing.T) {
 func TestCustomerShouldValidateWithEmail(t *testing.T) {
 	customer := model.NewCustomer()
 	customer.SetName("Jane Doe").
-		SetCpfCnpj("00000000192").
+		SetCpfCnpj("00000000272").
 		SetEmail("teste@testando.com")
 	require.NoError(t, customer.Validate(), "Customer should be valid with email")
 }
@@ -26,19 +28,25 @@ func TestCustomerShouldValidateWithEmail(t *testing.T) {
 func TestCustomerShouldNotValidateWithoutContactInfo(t *testing.T) {
 	customer := model.NewCustomer()
 	customer.SetName("No Contact").
-		SetCpfCnpj("00000000193")
+		SetCpfCnpj("00000000353")
 	err := customer.Validate()
 	require.Error(t, err, "Customer should not be valid without contact info")
-	require.ErrorIs(t, model.ErrNoContactInfo, err, "Error should be ErrNoContactInfo")
+	require.ErrorIs(t, err, model.ErrNoContactInfo, "Error should be ErrNoContactInfo")
+	var fieldErr *model.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.Equal(t, "mobilePhone", fieldErr.Field)
 }
 
 func TestCustomerShouldNotValidateWithoutName(t *testing.T) {
 	customer := model.NewCustomer()
-	customer.SetCpfCnpj("00000000194").
+	customer.SetCpfCnpj("00000000434").
 		SetMobilePhone("11999999999")
 	err := customer.Validate()
 	require.Error(t, err, "Customer should not be valid without name")
-	require.ErrorIs(t, model.ErrNameIsRequired, err, "Error should be ErrNameIsRequired")
+	require.ErrorIs(t, err, model.ErrNameIsRequired, "Error should be ErrNameIsRequired")
+	var fieldErr *model.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.Equal(t, "name", fieldErr.Field)
 }
 
 func TestCustomerShouldNotValidateWithoutDocument(t *testing.T) {
@@ -47,7 +55,10 @@ func TestCustomerShouldNotValidateWithoutDocument(t *testing.T) {
 		SetMobilePhone("11999999999")
 	err := customer.Validate()
 	require.Error(t, err, "Customer should not be valid without document")
-	require.ErrorIs(t, model.ErrDocumentIsRequired, err, "Error should be ErrDocumentIsRequired")
+	require.ErrorIs(t, err, model.ErrDocumentIsRequired, "Error should be ErrDocumentIsRequired")
+	var fieldErr *model.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.Equal(t, "cpfCnpj", fieldErr.Field)
 }
 
 func TestCustomerShouldUnmarshal(t *testing.T) {
@@ -57,5 +68,106 @@ func TestCustomerShouldUnmarshal(t *testing.T) {
 	require.Equal(t, "John Doe", customer.Name, "Customer name should match")
 	require.Equal(t, "00000000191", customer.CpfCnpj, "Customer CPF/CNPJ should match")
 	require.Equal(t, "31986058910", customer.MobilePhone, "Customer mobile phone should match")
-	require.Equal(t, "FISICA", customer.PersonType, "Customer person type should match")
+	require.Equal(t, model.PERSON_TYPE_FISICA, customer.PersonType, "Customer person type should match")
+}
+
+func TestCustomerShouldUnmarshalWithoutContactInfo(t *testing.T) {
+	customer := model.NewCustomer()
+	require.NoError(t, customer.Unmarshal([]byte(`{"id":"cus_000006724433","name":"John Doe","cpfCnpj":"00000000191","email":null,"mobilePhone":null}`)), "Asaas does not require contact info")
+	require.Empty(t, customer.Email)
+	require.ErrorIs(t, model.NewCustomer().Unmarshal([]byte(`{"name":"John Doe"}`)), model.ErrCustomerIDIsRequired)
+}
+
+func TestCustomerShouldRejectInvalidDocument(t *testing.T) {
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("000.000.001-92").
+		SetMobilePhone("11999999999")
+	err := customer.Validate()
+	var fieldError *model.FieldError
+	require.ErrorAs(t, err, &fieldError, "error should tell the field")
+	require.Equal(t, "cpfCnpj", fieldError.Field)
+	require.ErrorIs(t, err, document.ErrInvalidCPF)
+}
+
+func TestCustomerShouldCheckPersonTypeAgainstDocument(t *testing.T) {
+	customer := model.NewCustomer().
+		SetName("ACME").
+		SetCpfCnpj("12.ABC.345/01DE-35").
+		SetEmail("acme@example.com")
+	require.Equal(t, "12ABC34501DE35", customer.CpfCnpj, "document should be normalized")
+	require.Equal(t, "12.ABC.345/01DE-35", customer.FormattedCpfCnpj())
+	require.NoError(t, customer.Validate())
+	personType, err := model.PersonTypeOf(customer.CpfCnpj)
+	require.NoError(t, err)
+	require.Equal(t, model.PERSON_TYPE_JURIDICA, personType)
+	require.NoError(t, customer.SetPersonType(model.PERSON_TYPE_JURIDICA).Validate())
+	err = customer.SetPersonType(model.PERSON_TYPE_FISICA).Validate()
+	require.ErrorIs(t, err, model.ErrPersonTypeMismatch)
+	var fieldError *model.FieldError
+	require.ErrorAs(t, err, &fieldError)
+	require.Equal(t, "personType", fieldError.Field)
+}
+
+func TestCustomerShouldUnmarshalEveryField(t *testing.T) {
+	data := []byte(`{"object":"customer","id":"cus_000005219613","dateCreated":"2024-07-12","name":"ACME Ltda","email":"financeiro@acme.com.br","company":"ACME","phone":"4738010919","mobilePhone":"4799376637","address":"Av. Paulista","addressNumber":"150","complement":"Sala 201","province":"Centro","postalCode":"01310000","cpfCnpj":"11222333000181","personType":"JURIDICA","deleted":true,"additionalEmails":"a@acme.com.br,b@acme.com.br","externalReference":"12987382","notificationDisabled":true,"observations":"ótimo pagador","municipalInscription":"46683695908","stateInscription":"646681195275","groupName":"Atacado","foreignCustomer":false,"city":15873,"cityName":"São Paulo","state":"SP","country":"Brasil"}`)
+	customer := model.NewCustomer()
+	require.NoError(t, customer.Unmarshal(data), "Customer should unmarshal successfully")
+	require.Equal(t, "ACME", customer.Company)
+	require.Equal(t, "4738010919", customer.Phone)
+	require.Equal(t, []string{"a@acme.com.br", "b@acme.com.br"}, customer.GetAdditionalEmails())
+	require.Equal(t, "Av. Paulista", customer.Address)
+	require.Equal(t, "150", customer.AddressNumber)
+	require.Equal(t, "Sala 201", customer.Complement)
+	require.Equal(t, "Centro", customer.Province)
+	require.Equal(t, "01310000", customer.PostalCode)
+	require.Equal(t, 15873, customer.City)
+	require.Equal(t, "São Paulo", customer.CityName)
+	require.Equal(t, "SP", customer.State)
+	require.Equal(t, "Brasil", customer.Country)
+	require.Equal(t, "46683695908", customer.MunicipalInscription)
+	require.Equal(t, "646681195275", customer.StateInscription)
+	require.Equal(t, "ótimo pagador", customer.Observations)
+	require.Equal(t, "Atacado", customer.GroupName)
+	require.True(t, customer.NotificationDisabled)
+	require.False(t, customer.ForeignCustomer)
+	require.True(t, customer.Deleted)
+	require.Equal(t, model.NewDate(2024, time.July, 12), customer.DateCreated)
+	require.True(t, customer.HasAddress())
+}
+
+func TestCustomerShouldSendEveryWritableField(t *testing.T) {
+	customer := model.NewCustomer().
+		SetName("ACME Ltda").
+		SetCpfCnpj("11.222.333/0001-81").
+		SetEmail("financeiro@acme.com.br").
+		SetPhone("4738010919").
+		SetAdditionalEmails("a@acme.com.br", "b@acme.com.br").
+		SetCompany("ACME").
+		SetGroupName("Atacado").
+		SetAddress("Av. Paulista", "150", "Sala 201", "Centro", "01310-000").
+		SetMunicipalInscription("46683695908").
+		SetStateInscription("646681195275").
+		SetObservations("ótimo pagador").
+		SetNotificationDisabled(true)
+	result := customer.ToMap()
+	require.Equal(t, map[string]interface{}{
+		"name":                 "ACME Ltda",
+		"cpfCnpj":              "11222333000181",
+		"email":                "financeiro@acme.com.br",
+		"phone":                "4738010919",
+		"additionalEmails":     "a@acme.com.br,b@acme.com.br",
+		"company":              "ACME",
+		"groupName":            "Atacado",
+		"address":              "Av. Paulista",
+		"addressNumber":        "150",
+		"complement":           "Sala 201",
+		"province":             "Centro",
+		"postalCode":           "01310000",
+		"municipalInscription": "46683695908",
+		"stateInscription":     "646681195275",
+		"observations":         "ótimo pagador",
+		"notificationDisabled": true,
+		"foreignCustomer":      false,
+	}, result)
 }
@@ -0,0 +1,49 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/document"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerUpdateShouldSendOnlyDefinedFields(t *testing.T) {
+	update := model.NewCustomerUpdate().
+		SetEmail("john@example.com").
+		SetComplement("").
+		SetNotificationDisabled(false)
+	require.NoError(t, update.Validate(), "partial update should not require name or document")
+	require.Equal(t, map[string]interface{}{
+		"email":                "john@example.com",
+		"complement":           "",
+		"notificationDisabled": false,
+	}, update.ToMap())
+	require.True(t, update.Has("complement"))
+	require.False(t, update.Has("name"))
+}
+
+func TestCustomerUpdateShouldRequireSomeField(t *testing.T) {
+	require.ErrorIs(t, model.NewCustomerUpdate().Validate(), model.ErrNothingToUpdate)
+	var update *model.CustomerUpdate
+	require.ErrorIs(t, update.Validate(), model.ErrNothingToUpdate)
+}
+
+func TestCustomerUpdateShouldValidateChangedFields(t *testing.T) {
+	require.ErrorIs(t, model.NewCustomerUpdate().SetName("").Validate(), model.ErrNameIsRequired)
+	require.ErrorIs(t, model.NewCustomerUpdate().SetCpfCnpj("").Validate(), model.ErrDocumentIsRequired)
+	require.ErrorIs(t, model.NewCustomerUpdate().SetCpfCnpj("000.000.001-92").Validate(), document.ErrInvalidCPF)
+	err := model.NewCustomerUpdate().SetMobilePhone("").SetEmail("").Validate()
+	require.ErrorIs(t, err, model.ErrNoContactInfo)
+	var contactError *model.FieldError
+	require.ErrorAs(t, err, &contactError)
+	require.Equal(t, "mobilePhone", contactError.Field, "the rule should name the same field as Customer.Validate")
+	require.ErrorIs(t, model.NewCustomerUpdate().SetPersonType("OTHER").Validate(), model.ErrInvalidPersonType)
+	err = model.NewCustomerUpdate().SetCpfCnpj("11.222.333/0001-81").SetPersonType(model.PERSON_TYPE_FISICA).Validate()
+	require.ErrorIs(t, err, model.ErrPersonTypeMismatch)
+	var fieldError *model.FieldError
+	require.ErrorAs(t, err, &fieldError)
+	require.Equal(t, "personType", fieldError.Field)
+	require.NoError(t, model.NewCustomerUpdate().SetCpfCnpj("11.222.333/0001-81").SetPersonType(model.PERSON_TYPE_JURIDICA).Validate())
+	require.NoError(t, model.NewCustomerUpdate().SetMobilePhone("").Validate(), "clearing one contact should be allowed")
+}
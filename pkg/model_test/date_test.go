@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateShouldUseSaoPauloTime(t *testing.T) {
+	date := model.NewDate(2025, time.July, 24)
+	require.Equal(t, "America/Sao_Paulo", date.Time().Location().String())
+	// 01:30 UTC is still the previous day in São Paulo
+	require.Equal(t, model.NewDate(2025, time.July, 23), model.DateOf(time.Date(2025, time.July, 24, 1, 30, 0, 0, time.UTC)))
+	require.Equal(t, "2025-07-24", date.String())
+}
+
+func TestDateShouldDoArithmetic(t *testing.T) {
+	date := model.NewDate(2025, time.January, 31)
+	require.Equal(t, model.NewDate(2025, time.February, 1), date.AddDays(1))
+	require.Equal(t, model.NewDate(2025, time.March, 3), date.AddDate(0, 1, 0))
+	require.Equal(t, 365, date.DaysUntil(date.AddDate(1, 0, 0)))
+	require.Equal(t, -31, date.DaysUntil(model.NewDate(2024, time.December, 31)))
+	require.True(t, date.Before(date.AddDays(1)))
+	require.True(t, date.After(date.AddDays(-1)))
+	require.True(t, date.Equal(model.NewDate(2025, time.January, 31)))
+}
+
+func TestDateShouldMarshalJSON(t *testing.T) {
+	var data struct {
+		DueDate   model.Date `json:"dueDate"`
+		CreditDay model.Date `json:"creditDate"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"dueDate":"2021-01-01","creditDate":null}`), &data))
+	require.Equal(t, model.NewDate(2021, time.January, 1), data.DueDate)
+	require.True(t, data.CreditDay.IsZero())
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dueDate":"2021-01-01","creditDate":null}`, string(encoded))
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"dueDate":"01/01/2021"}`), &data), model.ErrInvalidDate)
+}
+
+func TestDateTimeShouldParseAsaasTimestamps(t *testing.T) {
+	dateTime, err := model.ParseDateTime("2024-06-12 16:45:03")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.June, 12, 19, 45, 3, 0, time.UTC), dateTime.Time().UTC())
+	require.Equal(t, model.NewDate(2024, time.June, 12), dateTime.Date())
+	require.Equal(t, "2024-06-12 16:45:03", dateTime.String())
+	other, err := model.ParseDateTime("2024-06-12T19:45:03Z")
+	require.NoError(t, err)
+	require.True(t, dateTime.Equal(other))
+	_, err = model.ParseDateTime("12/06/2024")
+	require.ErrorIs(t, err, model.ErrInvalidDateTime)
+	var data struct {
+		DateCreated model.DateTime `json:"dateCreated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"dateCreated":"2024-06-12 16:45:03"}`), &data))
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dateCreated":"2024-06-12 16:45:03"}`, string(encoded))
+}
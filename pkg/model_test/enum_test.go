@@ -0,0 +1,82 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumsShouldValidateKnownValues(t *testing.T) {
+	require.True(t, model.BILLING_TYPE_PIX.Valid())
+	require.False(t, model.BillingType("CASH").Valid())
+	require.True(t, model.CYCLE_SEMIANNUALLY.Valid())
+	require.False(t, model.Cycle("DAILY").Valid())
+	require.True(t, model.PERSON_TYPE_JURIDICA.Valid())
+	require.False(t, model.PersonType("OTHER").Valid())
+	require.True(t, model.PAYMENT_STATUS_RECEIVED_IN_CASH.Valid())
+	require.False(t, model.PaymentStatus("PAID").Valid())
+	require.True(t, model.EVENT_SUBSCRIPTION_INACTIVATED.Valid())
+	require.False(t, model.WebhookEvent("PAYMENT_PAID").Valid())
+}
+
+func TestEnumsShouldAcceptUnknownValuesByDefault(t *testing.T) {
+	var data struct {
+		BillingType model.BillingType `json:"billingType"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"billingType":"NEW_TYPE"}`), &data))
+	require.Equal(t, model.BillingType("NEW_TYPE"), data.BillingType)
+	_, err := json.Marshal(data)
+	require.NoError(t, err)
+}
+
+func TestEnumsShouldValidateThemselves(t *testing.T) {
+	require.NoError(t, model.CYCLE_YEARLY.Validate())
+	require.NoError(t, model.PersonType("").Validate(), "empty values should be accepted")
+	err := model.Cycle("DAILY").Validate()
+	require.ErrorIs(t, err, model.ErrInvalidCycle)
+	require.ErrorIs(t, err, model.ErrInvalidEnum)
+	require.ErrorIs(t, model.PersonType("OTHER").Validate(), model.ErrInvalidPersonType)
+	require.ErrorIs(t, model.NotificationChannel("FAX").Validate(), model.ErrInvalidChannel)
+}
+
+func TestDecodeStrictShouldRejectUnknownValues(t *testing.T) {
+	var data struct {
+		Cycle  model.Cycle          `json:"cycle"`
+		Status *model.PaymentStatus `json:"status"`
+		Events []model.WebhookEvent `json:"events"`
+	}
+	err := model.DecodeStrict([]byte(`{"cycle":"DAILY"}`), &data)
+	require.ErrorIs(t, err, model.ErrInvalidCycle)
+	require.ErrorIs(t, err, model.ErrInvalidEnum)
+	require.ErrorIs(t, model.DecodeStrict([]byte(`{"cycle":"","status":"PAID"}`), &data), model.ErrInvalidPaymentStatus)
+	require.ErrorIs(t, model.DecodeStrict([]byte(`{"status":null,"events":["PAYMENT_OVERDUE","PAYMENT_PAID"]}`), &data), model.ErrInvalidWebhookEvent)
+	require.NoError(t, model.DecodeStrict([]byte(`{"cycle":"YEARLY","status":"OVERDUE","events":["PAYMENT_OVERDUE"]}`), &data))
+	require.Equal(t, model.CYCLE_YEARLY, data.Cycle)
+	require.Error(t, model.DecodeStrict([]byte(`{"cycle":`), &data), "malformed JSON should fail")
+}
+
+func TestDecodeStrictShouldNotAffectOtherDecoders(t *testing.T) {
+	var payment model.Payment
+	require.Error(t, model.DecodeStrict([]byte(`{"billingType":"CASH"}`), &payment))
+	require.NoError(t, json.Unmarshal([]byte(`{"billingType":"CASH"}`), &payment), "plain decoding should stay lenient")
+	require.Equal(t, model.BillingType("CASH"), payment.BillingType)
+}
+
+func TestValidateShouldRejectUnknownEnums(t *testing.T) {
+	subscription := model.NewSubscription().
+		SetCustomerID("12345").
+		SetBillingType(model.BILLING_TYPE_BOLETO).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
+		SetCycle("DAILY")
+	require.ErrorIs(t, subscription.Validate(), model.ErrInvalidCycle)
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetEmail("john@example.com").
+		SetPersonType("OTHER")
+	require.ErrorIs(t, customer.Validate(), model.ErrInvalidPersonType)
+}
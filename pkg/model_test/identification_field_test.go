@@ -0,0 +1,25 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/boleto"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentIdentificationFieldShouldUnmarshal(t *testing.T) {
+	data := []byte(`{"identificationField":"00190500954014481606906809350314337370000000100","nossoNumero":"6453","barCode":"00193373700000001000500940144816060680935031"}`)
+	field := model.NewPaymentIdentificationField()
+	require.NoError(t, field.Unmarshal(data))
+	require.Equal(t, "6453", field.NossoNumero)
+	require.Equal(t, "00190.50095 40144.816069 06809.350314 3 37370000000100", field.FormattedIdentificationField())
+	require.Equal(t, model.Cents(100), field.Value())
+}
+
+func TestPaymentIdentificationFieldShouldRefuseCorruptedLine(t *testing.T) {
+	corrupted := []byte(`{"identificationField":"00190500954014481606906809350314337370000000200","nossoNumero":"6453","barCode":"00193373700000001000500940144816060680935031"}`)
+	require.ErrorIs(t, model.NewPaymentIdentificationField().Unmarshal(corrupted), boleto.ErrInvalidLine)
+	mismatched := []byte(`{"identificationField":"00190500954014481606906809350314337370000000100","nossoNumero":"6453","barCode":"46193160300000129900000000000000000000006453"}`)
+	require.ErrorIs(t, model.NewPaymentIdentificationField().Unmarshal(mismatched), boleto.ErrLineMismatch)
+}
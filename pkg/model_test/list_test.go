@@ -0,0 +1,25 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListShouldUnmarshal(t *testing.T) {
+	data := []byte(`{"object":"list","hasMore":true,"totalCount":3,"limit":2,"offset":0,"data":[{"id":"cus_1","name":"John Doe"},{"id":"cus_2","name":"Jane Doe"}]}`)
+	list := model.NewCustomerList()
+	require.NoError(t, list.Unmarshal(data), "List should unmarshal successfully")
+	require.True(t, list.HasMore)
+	require.Equal(t, 3, list.TotalCount)
+	require.Len(t, list.Data, 2)
+	require.Equal(t, "cus_2", list.Data[1].ID)
+	require.Equal(t, 2, list.NextOffset())
+}
+
+func TestListShouldStartWithDefaults(t *testing.T) {
+	list := model.NewList[model.Subscription]()
+	require.Equal(t, model.DEFAULT_LIST_LIMIT, list.Limit)
+	require.Empty(t, list.Data)
+}
@@ -0,0 +1,74 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyShouldParseDecimalsExactly(t *testing.T) {
+	cases := map[string]int64{
+		"19.99":   1999,
+		"0.1":     10,
+		"100":     10000,
+		"-3.5":    -350,
+		"1e2":     10000,
+		"94.515":  9452,
+		"-94.515": -9452,
+		"0.004":   0,
+	}
+	for value, cents := range cases {
+		money, err := model.ParseMoney(value)
+		require.NoError(t, err, "should parse %s", value)
+		require.Equal(t, cents, money.Cents(), "should parse %s", value)
+	}
+	_, err := model.ParseMoney("19,99")
+	require.ErrorIs(t, err, model.ErrInvalidMoney)
+	_, err = model.ParseMoney("")
+	require.ErrorIs(t, err, model.ErrInvalidMoney)
+}
+
+func TestMoneyShouldUnmarshalAsaasNumbers(t *testing.T) {
+	var data struct {
+		Value    model.Money `json:"value"`
+		NetValue model.Money `json:"netValue"`
+		Original model.Money `json:"originalValue"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"value":19.99,"netValue":"18.50","originalValue":null}`), &data))
+	require.Equal(t, model.Cents(1999), data.Value, "19.99 should not be truncated")
+	require.Equal(t, model.Cents(1850), data.NetValue)
+	require.True(t, data.Original.IsZero())
+	require.Error(t, json.Unmarshal([]byte(`{"value":"abc"}`), &data))
+	require.Error(t, json.Unmarshal([]byte(`{"value":true}`), &data))
+}
+
+func TestMoneyShouldMarshalAsDecimal(t *testing.T) {
+	data, err := json.Marshal(map[string]model.Money{"value": model.Cents(1999), "fee": model.Cents(-5)})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value":19.99,"fee":-0.05}`, string(data))
+	require.Equal(t, "100.00", model.Reais(100).String())
+}
+
+func TestMoneyShouldDoArithmetic(t *testing.T) {
+	value := model.Cents(1999)
+	require.Equal(t, model.Cents(3998), value.Add(value))
+	require.Equal(t, model.Cents(1), model.Reais(20).Sub(value))
+	require.Equal(t, model.Cents(5997), value.Mul(3))
+	require.Equal(t, model.Cents(-1999), value.Neg())
+	require.True(t, value.LessThan(model.Reais(20)))
+	require.True(t, model.Reais(20).GreaterThan(value))
+	require.Equal(t, 0, value.Cmp(model.Cents(1999)))
+	require.Equal(t, -1, value.Cmp(model.Reais(20)))
+	require.True(t, value.Equal(model.Cents(1999)))
+	require.True(t, value.IsPositive())
+	require.True(t, value.Neg().IsNegative())
+}
+
+func TestMoneyShouldFormatAsBRL(t *testing.T) {
+	require.Equal(t, "R$ 19,99", model.Cents(1999).Format())
+	require.Equal(t, "R$ 0,05", model.Cents(5).Format())
+	require.Equal(t, "R$ 1.234.567,89", model.Cents(123456789).Format())
+	require.Equal(t, "-R$ 1.000,00", model.Reais(-1000).Format())
+}
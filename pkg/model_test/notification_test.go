@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationShouldUnmarshal(t *testing.T) {
+	data := []byte(`{"object":"notification","id":"not_V9sCXq0uV3Be","customer":"cus_000005219613","enabled":true,"emailEnabledForProvider":true,"smsEnabledForProvider":false,"emailEnabledForCustomer":true,"smsEnabledForCustomer":true,"phoneCallEnabledForCustomer":false,"whatsappEnabledForCustomer":true,"event":"PAYMENT_DUEDATE_WARNING","scheduleOffset":10,"deleted":false}`)
+	notification := model.NewNotification()
+	require.NoError(t, notification.Unmarshal(data))
+	require.Equal(t, model.NOTIFICATION_PAYMENT_DUEDATE_WARNING, notification.Event)
+	require.Equal(t, 10, notification.ScheduleOffset)
+	require.Equal(t, []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_SMS, model.CHANNEL_WHATSAPP}, notification.Channels())
+}
+
+func TestNotificationShouldSetChannels(t *testing.T) {
+	notification := model.NewNotification().
+		SetID("not_1").
+		SetEnabled(true).
+		SetChannel(model.CHANNEL_VOICE, true).
+		SetChannel(model.CHANNEL_SMS, false).
+		SetProviderChannel(model.CHANNEL_EMAIL, true).
+		SetProviderChannel(model.CHANNEL_WHATSAPP, true).
+		SetScheduleOffset(5)
+	require.NoError(t, notification.Validate())
+	require.Equal(t, map[string]interface{}{
+		"enabled":                     true,
+		"emailEnabledForProvider":     true,
+		"smsEnabledForProvider":       false,
+		"emailEnabledForCustomer":     false,
+		"smsEnabledForCustomer":       false,
+		"phoneCallEnabledForCustomer": true,
+		"whatsappEnabledForCustomer":  false,
+		"scheduleOffset":              5,
+	}, notification.ToMap(), "provider copies should only go by email or SMS")
+}
+
+func TestNotificationShouldValidate(t *testing.T) {
+	require.ErrorIs(t, model.NewNotification().Validate(), model.ErrNotificationIDIsRequired)
+	require.ErrorIs(t, model.NewNotification().SetID("not_1").SetScheduleOffset(3).Validate(), model.ErrInvalidScheduleOffset)
+	require.ErrorIs(t, model.NewNotificationBatch("").Validate(), model.ErrCustomerIDIsRequired)
+	require.ErrorIs(t, model.NewNotificationBatch("cus_1").Validate(), model.ErrNoNotifications)
+	batch := model.NewNotificationBatch("cus_1").Add(*model.NewNotification())
+	require.ErrorIs(t, batch.Validate(), model.ErrNotificationIDIsRequired)
+}
+
+func TestNotificationProfileShouldApplyTemplates(t *testing.T) {
+	profile := model.NewNotificationProfile(
+		model.NotificationTemplate{
+			Event:          model.NOTIFICATION_PAYMENT_DUEDATE_WARNING,
+			Enabled:        true,
+			Channels:       []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_WHATSAPP},
+			ScheduleOffset: 10,
+		},
+		model.NotificationTemplate{Event: model.NOTIFICATION_SEND_LINHA_DIGITAVEL},
+	)
+	require.NoError(t, profile.Validate())
+	notifications := []model.Notification{
+		{ID: "not_1", Event: model.NOTIFICATION_PAYMENT_DUEDATE_WARNING, Enabled: true, SmsEnabledForCustomer: true, ScheduleOffset: 10},
+		{ID: "not_2", Event: model.NOTIFICATION_PAYMENT_DUEDATE_WARNING, Enabled: true, SmsEnabledForCustomer: true, ScheduleOffset: 0},
+		{ID: "not_3", Event: model.NOTIFICATION_SEND_LINHA_DIGITAVEL, Enabled: true, EmailEnabledForCustomer: true},
+		{ID: "not_4", Event: model.NOTIFICATION_PAYMENT_RECEIVED, Enabled: true},
+	}
+	changed := profile.Apply(notifications)
+	require.Len(t, changed, 2, "notifications without template should be left out")
+	require.Equal(t, "not_1", changed[0].ID, "the schedule offset should pick the notification")
+	require.Equal(t, []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_WHATSAPP}, changed[0].Channels())
+	require.Equal(t, 10, changed[0].ScheduleOffset)
+	require.Equal(t, "not_3", changed[1].ID)
+	require.False(t, changed[1].Enabled)
+	require.Empty(t, changed[1].Channels())
+	require.True(t, notifications[2].EmailEnabledForCustomer, "original notifications should not change")
+	invalid := model.NewNotificationProfile(model.NotificationTemplate{Event: model.NOTIFICATION_PAYMENT_CREATED, Channels: []model.NotificationChannel{"FAX"}})
+	require.ErrorIs(t, invalid.Validate(), model.ErrInvalidChannel)
+}
+
+func TestNotificationProfileShouldRejectDuplicateTemplates(t *testing.T) {
+	profile := model.NewNotificationProfile(
+		model.NotificationTemplate{Event: model.NOTIFICATION_PAYMENT_OVERDUE},
+		model.NotificationTemplate{Event: model.NOTIFICATION_PAYMENT_OVERDUE, ScheduleOffset: 7},
+	)
+	require.NoError(t, profile.Validate(), "the same event may have several offsets")
+	profile.Templates = append(profile.Templates, model.NotificationTemplate{Event: model.NOTIFICATION_PAYMENT_OVERDUE, Enabled: true})
+	require.ErrorIs(t, profile.Validate(), model.ErrDuplicateTemplate)
+}
@@ -0,0 +1,115 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentShouldUnmarshal(t *testing.T) {
+	data := []byte(`{"object":"payment","id":"pay_080225913252","dateCreated":"2026-05-10","customer":"cus_G7Dvo4iphUNk","subscription":null,"value":129.9,"netValue":127.91,"description":"Pedido 056984","billingType":"BOLETO","status":"RECEIVED","dueDate":"2026-05-15","originalDueDate":"2026-05-15","paymentDate":"2026-05-14","clientPaymentDate":"2026-05-14","invoiceUrl":"https://www.asaas.com/i/080225913252","bankSlipUrl":"https://www.asaas.com/b/pdf/080225913252","nossoNumero":"6453","externalReference":"056984","deleted":false,"postalService":false}`)
+	payment := model.NewPayment()
+	require.NoError(t, payment.Unmarshal(data))
+	require.Equal(t, "pay_080225913252", payment.ID)
+	require.Equal(t, model.Cents(12990), payment.Value)
+	require.Equal(t, model.Cents(12791), payment.NetValue)
+	require.Equal(t, model.NewDate(2026, time.May, 15), payment.DueDate)
+	require.Equal(t, model.NewDate(2026, time.May, 14), payment.PaymentDate)
+	require.True(t, payment.IsBoleto())
+	require.True(t, payment.IsPaid())
+	require.ErrorIs(t, model.NewPayment().Unmarshal([]byte(`{"id":"pay_1"}`)), model.ErrCustomerIDIsRequired)
+}
+
+func TestPaymentShouldValidate(t *testing.T) {
+	dueDate := model.NewDate(2026, time.December, 10)
+	require.ErrorIs(t, model.NewPayment().Validate(), model.ErrCustomerIDIsRequired)
+	require.ErrorIs(t, model.NewPayment().SetCustomerID("cus_1").Validate(), model.ErrBillingTypeIsRequired)
+	require.ErrorIs(t, model.NewPayment().SetCustomerID("cus_1").SetBillingType(model.BILLING_TYPE_PIX).Validate(), model.ErrDueDateIsRequired)
+	payment := model.NewPayment().SetCustomerID("cus_1").SetBillingType("CHEQUE").SetDueDate(dueDate).SetValue(model.Reais(10))
+	require.ErrorIs(t, payment.Validate(), model.ErrInvalidBillingType)
+	payment.SetBillingType(model.BILLING_TYPE_PIX).SetValue(model.Cents(0))
+	require.ErrorIs(t, payment.Validate(), model.ErrValueMustBePositive)
+	payment.SetValue(model.Cents(1990))
+	require.NoError(t, payment.Validate())
+}
+
+func TestPaymentShouldReportTheInvalidField(t *testing.T) {
+	dueDate := model.NewDate(2026, time.December, 10)
+	payments := map[string]*model.Payment{
+		"customer":    model.NewPayment(),
+		"billingType": model.NewPayment().SetCustomerID("cus_1").SetBillingType("CHEQUE").SetDueDate(dueDate),
+		"dueDate":     model.NewPayment().SetCustomerID("cus_1").SetBillingType(model.BILLING_TYPE_PIX),
+		"value":       model.NewPayment().SetCustomerID("cus_1").SetBillingType(model.BILLING_TYPE_PIX).SetDueDate(dueDate),
+	}
+	for field, payment := range payments {
+		var fieldErr *model.FieldError
+		require.ErrorAs(t, payment.Validate(), &fieldErr, field)
+		require.Equal(t, field, fieldErr.Field)
+	}
+}
+
+func TestPaymentShouldSendOnlyWritableFields(t *testing.T) {
+	payment := model.NewPayment().
+		SetCustomerID("cus_1").
+		SetBillingType(model.BILLING_TYPE_BOLETO).
+		SetValue(model.Cents(1990)).
+		SetDueDate(model.NewDate(2026, time.December, 10)).
+		SetDescription("Pedido 1")
+	payment.Status = model.PAYMENT_STATUS_RECEIVED
+	result := payment.ToMap()
+	require.Equal(t, model.Cents(1990), result["value"])
+	require.Equal(t, "Pedido 1", result["description"])
+	require.NotContains(t, result, "status")
+	require.NotContains(t, result, "externalReference", "empty optional fields should be left out")
+}
+
+func TestPaymentUpdateShouldValidateChangedFields(t *testing.T) {
+	require.ErrorIs(t, model.NewPaymentUpdate().Validate(), model.ErrNothingToUpdate)
+	var update *model.PaymentUpdate
+	require.ErrorIs(t, update.Validate(), model.ErrNothingToUpdate)
+	require.ErrorIs(t, model.NewPaymentUpdate().SetValue(model.Cents(-1)).Validate(), model.ErrValueMustBePositive)
+	require.ErrorIs(t, model.NewPaymentUpdate().SetBillingType("CHEQUE").Validate(), model.ErrInvalidBillingType)
+	require.ErrorIs(t, model.NewPaymentUpdate().SetDueDate(model.Date{}).Validate(), model.ErrDueDateIsRequired)
+	update = model.NewPaymentUpdate().SetDescription("").SetPostalService(false)
+	require.NoError(t, update.Validate())
+	require.Equal(t, map[string]interface{}{"description": "", "postalService": false}, update.ToMap())
+}
+
+func TestPaymentFilterShouldEncodeAsaasParameters(t *testing.T) {
+	filter := model.NewPaymentFilter().
+		SetCustomerID("cus_1").
+		SetSubscriptionID("sub_1").
+		SetStatus(model.PAYMENT_STATUS_OVERDUE).
+		SetBillingType(model.BILLING_TYPE_BOLETO).
+		SetDueDate(model.NewDate(2026, time.May, 1), model.NewDate(2026, time.May, 31)).
+		SetPaymentDate(model.NewDate(2026, time.June, 1), model.Date{}).
+		SetLimit(50)
+	require.NoError(t, filter.Validate())
+	require.Equal(t, map[string]interface{}{
+		"customer":        "cus_1",
+		"subscription":    "sub_1",
+		"status":          "OVERDUE",
+		"billingType":     "BOLETO",
+		"dueDate[ge]":     "2026-05-01",
+		"dueDate[le]":     "2026-05-31",
+		"paymentDate[ge]": "2026-06-01",
+		"limit":           50,
+	}, filter.ToMap())
+	require.Empty(t, model.NewPaymentFilter().ToMap(), "empty filter should send no parameters")
+}
+
+func TestPaymentFilterShouldValidate(t *testing.T) {
+	require.ErrorIs(t, model.NewPaymentFilter().SetLimit(101).Validate(), model.ErrInvalidLimit)
+	require.ErrorIs(t, model.NewPaymentFilter().SetStatus("PAID").Validate(), model.ErrInvalidPaymentStatus)
+	require.ErrorIs(t, model.NewPaymentFilter().SetBillingType("CHEQUE").Validate(), model.ErrInvalidBillingType)
+	filter := model.NewPaymentFilter().SetDateCreated(model.NewDate(2026, time.May, 2), model.NewDate(2026, time.May, 1))
+	require.ErrorIs(t, filter.Validate(), model.ErrInvalidDateRange)
+	var fieldError *model.FieldError
+	require.ErrorAs(t, filter.Validate(), &fieldError)
+	require.Equal(t, "dateCreated", fieldError.Field)
+	next := filter.WithOffset(10)
+	require.Equal(t, 10, next.Offset)
+	require.Equal(t, 0, filter.Offset, "original filter should not change")
+}
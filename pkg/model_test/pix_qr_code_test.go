@@ -0,0 +1,64 @@
+package model_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/pix"
+	"github.com/stretchr/testify/require"
+)
+
+func pixQrCodeData(t *testing.T, payload string) []byte {
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 4, 4))))
+	data, err := json.Marshal(map[string]string{
+		"encodedImage":   base64.StdEncoding.EncodeToString(buffer.Bytes()),
+		"payload":        payload,
+		"expirationDate": "2026-05-15 23:59:59",
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func pixPayload(t *testing.T, amount string) string {
+	payload, err := pix.Build(
+		pix.Field{ID: pix.ID_PAYLOAD_FORMAT, Value: pix.PAYLOAD_FORMAT},
+		pix.Field{ID: pix.ID_MERCHANT_ACCOUNT, Value: pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_GUI, Value: pix.PIX_GUI}) +
+			pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_URL, Value: "pix.asaas.com/qr/cobv/1"})},
+		pix.Field{ID: pix.ID_MERCHANT_CATEGORY_CODE, Value: "0000"},
+		pix.Field{ID: pix.ID_TRANSACTION_CURRENCY, Value: pix.CURRENCY_REAL},
+		pix.Field{ID: pix.ID_TRANSACTION_AMOUNT, Value: amount},
+		pix.Field{ID: pix.ID_COUNTRY_CODE, Value: "BR"},
+		pix.Field{ID: pix.ID_MERCHANT_NAME, Value: "ACME"},
+		pix.Field{ID: pix.ID_MERCHANT_CITY, Value: "BELO HORIZONTE"},
+	)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestPixQrCodeShouldUnmarshal(t *testing.T) {
+	qrCode := model.NewPixQrCode()
+	require.NoError(t, qrCode.Unmarshal(pixQrCodeData(t, pixPayload(t, "129.90"))))
+	require.Equal(t, 4, qrCode.Image.Bounds().Dx())
+	require.Equal(t, []byte("\x89PNG"), qrCode.PNG[:4])
+	require.True(t, qrCode.Details.IsDynamic())
+	require.Equal(t, model.Cents(12990), qrCode.Value())
+	expiration := model.NewDateTime(time.Date(2026, time.May, 15, 23, 59, 59, 0, model.Location()))
+	require.True(t, qrCode.ExpirationDate.Equal(expiration))
+	require.False(t, qrCode.IsExpired(expiration))
+	require.True(t, qrCode.IsExpired(model.NewDateTime(expiration.Time().Add(time.Second))))
+}
+
+func TestPixQrCodeShouldRefuseCorruptedData(t *testing.T) {
+	payload := pixPayload(t, "129.90")
+	corrupted := payload[:40] + "X" + payload[41:]
+	require.ErrorIs(t, model.NewPixQrCode().Unmarshal(pixQrCodeData(t, corrupted)), pix.ErrInvalidCRC)
+	invalidImage := []byte(`{"encodedImage":"bm90IGEgcG5n","payload":"` + payload + `","expirationDate":"2026-05-15 23:59:59"}`)
+	require.ErrorIs(t, model.NewPixQrCode().Unmarshal(invalidImage), model.ErrInvalidQrCodeImage)
+}
@@ -2,6 +2,7 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pericles-luz/go-asaas/pkg/model"
 	"github.com/stretchr/testify/require"
@@ -11,8 +12,8 @@ func TestSubscriptionShouldValidate(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetNextDueDate("2023-10-01").
-		SetValue(100.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	require.NoError(t, subscription.Validate(), "Subscription should be valid")
@@ -21,8 +22,8 @@ func TestSubscriptionShouldValidate(t *testing.T) {
 func TestSubscriptionShouldNotValidateWithoutCustomerID(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetBillingType("BOLETO").
-		SetNextDueDate("2023-10-01").
-		SetValue(100.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -33,8 +34,8 @@ func TestSubscriptionShouldNotValidateWithoutCustomerID(t *testing.T) {
 func TestSubscriptionShouldNotValidateWithoutBillingType(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
-		SetNextDueDate("2023-10-01").
-		SetValue(100.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -46,7 +47,7 @@ func TestSubscriptionShouldNotValidateWithoutNextDueDate(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetValue(100.0).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -58,8 +59,8 @@ func TestSubscriptionShouldNotValidateWithZeroValue(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetNextDueDate("2023-10-01").
-		SetValue(0.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(0)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -71,8 +72,8 @@ func TestSubscriptionShouldNotValidateWithNegativeValue(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetNextDueDate("2023-10-01").
-		SetValue(-50.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(-50)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -84,8 +85,8 @@ func TestSubscriptionShouldNotValidateWithoutCycle(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetNextDueDate("2023-10-01").
-		SetValue(100.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
 	require.Error(t, err, "Subscription should not be valid without Cycle")
@@ -93,14 +94,16 @@ func TestSubscriptionShouldNotValidateWithoutCycle(t *testing.T) {
 }
 
 func TestSubscriptionShouldNotValidateWithInvalidNextDueDate(t *testing.T) {
+	nextDueDate, err := model.ParseDate("invalid-date")
+	require.ErrorIs(t, err, model.ErrInvalidDate, "invalid date should not be parsed")
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("BOLETO").
-		SetNextDueDate("invalid-date").
-		SetValue(100.0).
+		SetNextDueDate(nextDueDate).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
-	err := subscription.Validate()
+	err = subscription.Validate()
 	require.Error(t, err, "Subscription should not be valid with invalid NextDueDate")
 	require.ErrorIs(t, model.ErrNextDueDateIsRequired, err, "Error should be ErrNextDueDateIsRequired")
 }
@@ -109,8 +112,8 @@ func TestSubscriptionShouldNotValidateIfBillingTypeIsNotBoleto(t *testing.T) {
 	subscription := model.NewSubscription().
 		SetCustomerID("12345").
 		SetBillingType("INVALID_TYPE").
-		SetNextDueDate("2023-10-01").
-		SetValue(100.0).
+		SetNextDueDate(model.NewDate(2023, time.October, 1)).
+		SetValue(model.Reais(100)).
 		SetCycle("MONTHLY").
 		SetDescription("Test Subscription")
 	err := subscription.Validate()
@@ -124,9 +127,27 @@ func TestSubscriptionShouldUnmarshal(t *testing.T) {
 	require.NoError(t, subscription.Unmarshal(data), "Subscription should unmarshal successfully")
 	require.Equal(t, "sub_1ifrhps9m8mwficw", subscription.ID, "Subscription ID should match")
 	require.Equal(t, "cus_000006724433", subscription.CustomerID, "Subscription CustomerID should match")
-	require.Equal(t, "BOLETO", subscription.BillingType, "Subscription BillingType should match")
-	require.Equal(t, 100.00, subscription.Value, "Subscription Value should match")
-	require.Equal(t, "2025-07-24", subscription.NextDueDate.Format("2006-01-02"), "Subscription NextDueDate should match")
-	require.Equal(t, "MONTHLY", subscription.Cycle, "Subscription Cycle should match")
+	require.Equal(t, model.BILLING_TYPE_BOLETO, subscription.BillingType, "Subscription BillingType should match")
+	require.Equal(t, model.Reais(100), subscription.Value, "Subscription Value should match")
+	require.Equal(t, model.NewDate(2025, time.July, 24), subscription.NextDueDate, "Subscription NextDueDate should match")
+	require.Equal(t, model.CYCLE_MONTHLY, subscription.Cycle, "Subscription Cycle should match")
 	require.Equal(t, "Monthly Subscription for John Doe", subscription.Description, "Subscription Description should match")
 }
+
+func TestSubscriptionShouldUnmarshalValuesRefusedOnCreation(t *testing.T) {
+	data := []byte(`{"id":"sub_1","customer":"cus_1","billingType":"PIX","nextDueDate":"2025-07-24","value":100,"cycle":"DAILY"}`)
+	subscription := model.NewSubscription()
+	require.NoError(t, subscription.Unmarshal(data), "subscriptions created elsewhere should be read")
+	require.Equal(t, model.BILLING_TYPE_PIX, subscription.BillingType)
+	require.Equal(t, model.Cycle("DAILY"), subscription.Cycle)
+	require.ErrorIs(t, subscription.Validate(), model.ErrInvalidCycle, "creation should still be refused")
+	subscription.SetCycle(model.CYCLE_MONTHLY)
+	require.ErrorIs(t, subscription.Validate(), model.ErrOnlyBoletoAllowed)
+}
+
+func TestSubscriptionShouldRejectInvalidNextDueDateOnUnmarshal(t *testing.T) {
+	data := []byte(`{"id":"sub_1","customer":"cus_1","billingType":"BOLETO","nextDueDate":"24/07/2025","value":100,"cycle":"MONTHLY"}`)
+	require.ErrorIs(t, model.NewSubscription().Unmarshal(data), model.ErrInvalidDate)
+	data = []byte(`{"id":"sub_1","customer":"cus_1","billingType":"BOLETO","nextDueDate":null,"value":100,"cycle":"MONTHLY"}`)
+	require.ErrorIs(t, model.NewSubscription().Unmarshal(data), model.ErrNextDueDateIsRequired)
+}
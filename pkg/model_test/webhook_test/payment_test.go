@@ -2,7 +2,9 @@ package webhooktest
 
 import (
 	"testing"
+	"time"
 
+	"github.com/pericles-luz/go-asaas/pkg/model"
 	"github.com/pericles-luz/go-asaas/pkg/model/webhook"
 	"github.com/stretchr/testify/require"
 )
@@ -98,12 +100,49 @@ func TestPaymentShouldUnmarshal(t *testing.T) {
 	entity := webhook.NewWebhookPayment()
 	require.NoError(t, entity.Unmarshal(data), "should unmarshal webhook data")
 	require.Equal(t, "evt_05b708f961d739ea7eba7e4db318f621&368604920", entity.EventID)
-	require.Equal(t, "PAYMENT_RECEIVED", entity.Event)
+	require.Equal(t, model.EVENT_PAYMENT_RECEIVED, entity.Event)
 	require.True(t, entity.IsPaid(), "should be a paid event")
-	require.Equal(t, "2024-06-12 16:45:03", entity.DateCreated)
+	require.Equal(t, "2024-06-12 16:45:03", entity.DateCreated.String())
 	require.Equal(t, 10001, entity.ValueAsInt())
 	require.Equal(t, 10001, entity.Amount())
 	require.Equal(t, "pay_080225913252", entity.ID())
-	require.Equal(t, "2021-01-01", entity.PaymentDate())
+	require.Equal(t, model.NewDate(2021, time.January, 1), entity.PaymentDate())
 	require.Equal(t, "sub_VXJBYgP2u0eO", entity.SubscriptionID())
 }
+
+func TestWebhookPaymentShouldNotTruncateCentavos(t *testing.T) {
+	data := []byte(`{"id":"evt_1","event":"PAYMENT_RECEIVED","payment":{"id":"pay_1","value":19.99,"netValue":18.99,"refunds":[{"id":"ref_1","value":0.29}]}}`)
+	entity := webhook.NewWebhookPayment()
+	require.NoError(t, entity.Unmarshal(data), "should unmarshal webhook data")
+	require.Equal(t, 1999, entity.ValueAsInt())
+	require.Equal(t, 1999, entity.Amount())
+	require.Equal(t, model.Cents(1899), entity.Payment.NetValue)
+	require.Equal(t, model.Cents(29), entity.Payment.Refunds[0].Value)
+}
+
+func TestWebhookPaymentShouldTypeBillingTypeAndStatus(t *testing.T) {
+	data := []byte(`{"id":"evt_1","event":"PAYMENT_CONFIRMED","payment":{"id":"pay_1","billingType":"PIX","status":"CONFIRMED"}}`)
+	entity := webhook.NewWebhookPayment()
+	require.NoError(t, entity.Unmarshal(data), "should unmarshal webhook data")
+	require.Equal(t, model.EVENT_PAYMENT_CONFIRMED, entity.Event)
+	require.Equal(t, model.BILLING_TYPE_PIX, entity.Payment.BillingType)
+	require.Equal(t, model.PAYMENT_STATUS_CONFIRMED, entity.Payment.Status)
+}
+
+func TestWebhookPaymentShouldRejectUnknownEventWhenStrict(t *testing.T) {
+	data := []byte(`{"id":"evt_1","event":"PAYMENT_TELEPORTED","payment":{"id":"pay_1","billingType":"PIX","status":"CONFIRMED"}}`)
+	require.NoError(t, webhook.NewWebhookPayment().Unmarshal(data), "plain decoding should accept new events")
+	err := model.DecodeStrict(data, webhook.NewWebhookPayment())
+	require.ErrorIs(t, err, model.ErrInvalidWebhookEvent)
+	require.ErrorIs(t, err, model.ErrInvalidEnum)
+	data = []byte(`{"id":"evt_1","event":"PAYMENT_CONFIRMED","payment":{"id":"pay_1","billingType":"CHEQUE","status":"CONFIRMED"}}`)
+	require.ErrorIs(t, model.DecodeStrict(data, webhook.NewWebhookPayment()), model.ErrInvalidBillingType, "nested enums should be checked")
+	payment := webhook.NewWebhookPayment()
+	require.NoError(t, model.DecodeStrict([]byte(`{"id":"evt_1","event":"PAYMENT_CONFIRMED","payment":{"id":"pay_1","billingType":"PIX","status":"CONFIRMED"}}`), payment))
+	require.True(t, payment.Event.Valid())
+}
+
+func TestDecodeStrictShouldRunTheModelChecks(t *testing.T) {
+	err := model.DecodeStrict([]byte(`{"id":"pay_1","billingType":"PIX"}`), model.NewPayment())
+	require.ErrorIs(t, err, model.ErrCustomerIDIsRequired, "the Unmarshal method of the model should be used")
+}
@@ -0,0 +1,220 @@
+// Package pix parses and checks the copy-and-paste payload (BR Code) of PIX
+// QR codes: EMV fields in ID, length and value form, ending with a CRC16
+// that protects the whole payload.
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid pix payload")
+	ErrInvalidCRC     = errors.New("pix payload CRC does not match")
+	ErrMissingField   = errors.New("pix payload is missing a required field")
+)
+
+const (
+	ID_PAYLOAD_FORMAT          = "00"
+	ID_POINT_OF_INITIATION     = "01"
+	ID_MERCHANT_ACCOUNT        = "26"
+	ID_MERCHANT_CATEGORY_CODE  = "52"
+	ID_TRANSACTION_CURRENCY    = "53"
+	ID_TRANSACTION_AMOUNT      = "54"
+	ID_COUNTRY_CODE            = "58"
+	ID_MERCHANT_NAME           = "59"
+	ID_MERCHANT_CITY           = "60"
+	ID_ADDITIONAL_DATA         = "62"
+	ID_CRC                     = "63"
+	ID_ACCOUNT_GUI             = "00"
+	ID_ACCOUNT_KEY             = "01"
+	ID_ACCOUNT_URL             = "25"
+	ID_ADDITIONAL_DATA_TXID    = "05"
+	PIX_GUI                    = "br.gov.bcb.pix"
+	PAYLOAD_FORMAT             = "01"
+	CURRENCY_REAL              = "986"
+	POINT_OF_INITIATION_UNIQUE = "12"
+)
+
+// Field is an EMV field of the payload
+type Field struct {
+	ID    string
+	Value string
+}
+
+// Payload is the data of a PIX QR code
+type Payload struct {
+	PointOfInitiation    string // "12" when the code can be paid only once
+	Key                  string // PIX key of static codes
+	URL                  string // location of the charge of dynamic codes
+	MerchantCategoryCode string
+	Currency             string
+	Amount               string // decimal in reais, empty when the payer chooses it
+	CountryCode          string
+	MerchantName         string
+	MerchantCity         string
+	TxID                 string
+	CRC                  string
+	Fields               []Field
+}
+
+// splits data into its EMV fields, without looking into nested templates
+func Parse(data string) ([]Field, error) {
+	result := []Field{}
+	for position := 0; position < len(data); {
+		if position+4 > len(data) {
+			return nil, ErrInvalidPayload
+		}
+		id := data[position : position+2]
+		length, err := strconv.Atoi(data[position+2 : position+4])
+		if err != nil || !isNumeric(id) || length < 0 || position+4+length > len(data) {
+			return nil, ErrInvalidPayload
+		}
+		result = append(result, Field{ID: id, Value: data[position+4 : position+4+length]})
+		position += 4 + length
+	}
+	return result, nil
+}
+
+// checks the CRC and the required fields, returning the payload data
+func ParsePayload(payload string) (*Payload, error) {
+	if err := ValidateCRC(payload); err != nil {
+		return nil, err
+	}
+	fields, err := Parse(payload)
+	if err != nil {
+		return nil, err
+	}
+	result := &Payload{Fields: fields}
+	values := map[string]*string{
+		ID_POINT_OF_INITIATION:    &result.PointOfInitiation,
+		ID_MERCHANT_CATEGORY_CODE: &result.MerchantCategoryCode,
+		ID_TRANSACTION_CURRENCY:   &result.Currency,
+		ID_TRANSACTION_AMOUNT:     &result.Amount,
+		ID_COUNTRY_CODE:           &result.CountryCode,
+		ID_MERCHANT_NAME:          &result.MerchantName,
+		ID_MERCHANT_CITY:          &result.MerchantCity,
+		ID_CRC:                    &result.CRC,
+	}
+	for _, field := range fields {
+		if value, ok := values[field.ID]; ok {
+			*value = field.Value
+		}
+	}
+	if fields[0].ID != ID_PAYLOAD_FORMAT || fields[0].Value != PAYLOAD_FORMAT {
+		return nil, ErrInvalidPayload
+	}
+	if err := result.parseMerchantAccount(fields); err != nil {
+		return nil, err
+	}
+	if additional, ok := find(fields, ID_ADDITIONAL_DATA); ok {
+		nested, err := Parse(additional)
+		if err != nil {
+			return nil, err
+		}
+		result.TxID, _ = find(nested, ID_ADDITIONAL_DATA_TXID)
+	}
+	if result.MerchantCategoryCode == "" || result.CountryCode == "" || result.MerchantName == "" || result.MerchantCity == "" {
+		return nil, ErrMissingField
+	}
+	if result.Currency != CURRENCY_REAL {
+		return nil, ErrInvalidPayload
+	}
+	return result, nil
+}
+
+// reads the PIX merchant account, which may use any ID from 26 to 51
+func (p *Payload) parseMerchantAccount(fields []Field) error {
+	for _, field := range fields {
+		id, _ := strconv.Atoi(field.ID)
+		if id < 26 || id > 51 {
+			continue
+		}
+		nested, err := Parse(field.Value)
+		if err != nil {
+			return err
+		}
+		if gui, _ := find(nested, ID_ACCOUNT_GUI); !strings.EqualFold(gui, PIX_GUI) {
+			continue
+		}
+		p.Key, _ = find(nested, ID_ACCOUNT_KEY)
+		p.URL, _ = find(nested, ID_ACCOUNT_URL)
+		if p.Key == "" && p.URL == "" {
+			return ErrMissingField
+		}
+		return nil
+	}
+	return ErrMissingField
+}
+
+// tells whether the code is dynamic, pointing to a charge instead of a key
+func (p *Payload) IsDynamic() bool {
+	return p.URL != ""
+}
+
+// checks that the payload ends with a CRC field matching the data before it
+func ValidateCRC(payload string) error {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != ID_CRC+"04" {
+		return ErrInvalidPayload
+	}
+	if !strings.EqualFold(CRC16(payload[:len(payload)-4]), payload[len(payload)-4:]) {
+		return ErrInvalidCRC
+	}
+	return nil
+}
+
+// encodes the fields and appends the CRC field
+func Build(fields ...Field) (string, error) {
+	var builder strings.Builder
+	for _, field := range fields {
+		if len(field.ID) != 2 || !isNumeric(field.ID) || len(field.Value) > 99 {
+			return "", ErrInvalidPayload
+		}
+		builder.WriteString(Encode(field))
+	}
+	builder.WriteString(ID_CRC + "04")
+	data := builder.String()
+	return data + CRC16(data), nil
+}
+
+// encodes a single field, such as a nested template
+func Encode(field Field) string {
+	return fmt.Sprintf("%s%02d%s", field.ID, len(field.Value), field.Value)
+}
+
+// computes the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF) used by
+// the BR Code, as four upper-case hex digits
+func CRC16(data string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+func find(fields []Field, id string) (string, bool) {
+	for _, field := range fields {
+		if field.ID == id {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+func isNumeric(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
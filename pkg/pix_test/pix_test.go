@@ -0,0 +1,82 @@
+package pix_test
+
+import (
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/pix"
+	"github.com/stretchr/testify/require"
+)
+
+// static code of the BR Code manual of the Banco Central
+const static = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestPixShouldComputeCRC16(t *testing.T) {
+	require.Equal(t, "29B1", pix.CRC16("123456789"))
+	require.NoError(t, pix.ValidateCRC(static))
+}
+
+func TestPixShouldParseStaticPayload(t *testing.T) {
+	payload, err := pix.ParsePayload(static)
+	require.NoError(t, err)
+	require.Equal(t, "123e4567-e12b-12d1-a456-426655440000", payload.Key)
+	require.False(t, payload.IsDynamic())
+	require.Equal(t, "0000", payload.MerchantCategoryCode)
+	require.Equal(t, pix.CURRENCY_REAL, payload.Currency)
+	require.Empty(t, payload.Amount)
+	require.Equal(t, "BR", payload.CountryCode)
+	require.Equal(t, "Fulano de Tal", payload.MerchantName)
+	require.Equal(t, "BRASILIA", payload.MerchantCity)
+	require.Equal(t, "***", payload.TxID)
+	require.Equal(t, "1D3D", payload.CRC)
+	require.Len(t, payload.Fields, 9)
+}
+
+func TestPixShouldRefuseCorruptedPayload(t *testing.T) {
+	corrupted := []string{
+		static[:len(static)-1] + "E",    // CRC digit
+		static[:60] + "X" + static[61:], // data covered by the CRC
+		static[:len(static)-8],          // no CRC field
+		"",
+	}
+	for _, payload := range corrupted {
+		_, err := pix.ParsePayload(payload)
+		require.Error(t, err, "%q should be refused", payload)
+	}
+	_, err := pix.ParsePayload(static[:60] + "X" + static[61:])
+	require.ErrorIs(t, err, pix.ErrInvalidCRC)
+	_, err = pix.ParsePayload(static[:len(static)-8])
+	require.ErrorIs(t, err, pix.ErrInvalidPayload)
+}
+
+func TestPixShouldBuildPayload(t *testing.T) {
+	account := pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_GUI, Value: pix.PIX_GUI}) +
+		pix.Encode(pix.Field{ID: pix.ID_ACCOUNT_KEY, Value: "123e4567-e12b-12d1-a456-426655440000"})
+	payload, err := pix.Build(
+		pix.Field{ID: pix.ID_PAYLOAD_FORMAT, Value: pix.PAYLOAD_FORMAT},
+		pix.Field{ID: pix.ID_MERCHANT_ACCOUNT, Value: account},
+		pix.Field{ID: pix.ID_MERCHANT_CATEGORY_CODE, Value: "0000"},
+		pix.Field{ID: pix.ID_TRANSACTION_CURRENCY, Value: pix.CURRENCY_REAL},
+		pix.Field{ID: pix.ID_COUNTRY_CODE, Value: "BR"},
+		pix.Field{ID: pix.ID_MERCHANT_NAME, Value: "Fulano de Tal"},
+		pix.Field{ID: pix.ID_MERCHANT_CITY, Value: "BRASILIA"},
+		pix.Field{ID: pix.ID_ADDITIONAL_DATA, Value: pix.Encode(pix.Field{ID: pix.ID_ADDITIONAL_DATA_TXID, Value: "***"})},
+	)
+	require.NoError(t, err)
+	require.Equal(t, static, payload)
+	_, err = pix.Build(pix.Field{ID: "1", Value: "x"})
+	require.ErrorIs(t, err, pix.ErrInvalidPayload)
+}
+
+func TestPixShouldRequireMerchantAccount(t *testing.T) {
+	payload, err := pix.Build(
+		pix.Field{ID: pix.ID_PAYLOAD_FORMAT, Value: pix.PAYLOAD_FORMAT},
+		pix.Field{ID: pix.ID_MERCHANT_CATEGORY_CODE, Value: "0000"},
+		pix.Field{ID: pix.ID_TRANSACTION_CURRENCY, Value: pix.CURRENCY_REAL},
+		pix.Field{ID: pix.ID_COUNTRY_CODE, Value: "BR"},
+		pix.Field{ID: pix.ID_MERCHANT_NAME, Value: "Fulano de Tal"},
+		pix.Field{ID: pix.ID_MERCHANT_CITY, Value: "BRASILIA"},
+	)
+	require.NoError(t, err)
+	_, err = pix.ParsePayload(payload)
+	require.ErrorIs(t, err, pix.ErrMissingField)
+}
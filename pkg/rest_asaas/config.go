@@ -0,0 +1,96 @@
+package rest_asaas
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrInvalidProxy       = errors.New("invalid proxy URL")
+	ErrUnsupportedSetting = errors.New("proxy and certificate settings need an *http.Transport")
+)
+
+const (
+	SANDBOX_LINK    = "https://api-sandbox.asaas.com"
+	PRODUCTION_LINK = "https://api.asaas.com"
+
+	DEFAULT_TIMEOUT    = 1 * time.Minute
+	DEFAULT_USER_AGENT = "go-asaas"
+)
+
+type Environment string
+
+const (
+	ENVIRONMENT_SANDBOX    Environment = "sandbox"
+	ENVIRONMENT_PRODUCTION Environment = "production"
+)
+
+// returns the API link of the environment
+func (e Environment) Link() string {
+	switch e {
+	case ENVIRONMENT_PRODUCTION:
+		return PRODUCTION_LINK
+	case ENVIRONMENT_SANDBOX:
+		return SANDBOX_LINK
+	}
+	return ""
+}
+
+// Config holds the HTTP settings of an Engine. Zero values fall back to safe
+// defaults: certificates are verified and requests time out after a minute.
+// NewClient validates it; callers of NewEngine should call Validate first.
+type Config struct {
+	// disables certificate verification, never use it against production
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+	// copied and used as the underlying client when set, so the caller's client
+	// is never changed; Transport still overrides its transport
+	HTTPClient *http.Client
+	Transport  http.RoundTripper
+	Proxy      string
+	UserAgent  string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:   DEFAULT_TIMEOUT,
+		UserAgent: DEFAULT_USER_AGENT,
+	}
+}
+
+// checks the settings that the HTTP client would otherwise ignore silently
+func (c Config) Validate() error {
+	if c.Proxy != "" {
+		proxy, err := url.Parse(c.Proxy)
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			return ErrInvalidProxy
+		}
+	}
+	if c.Proxy == "" && !c.InsecureSkipVerify {
+		return nil
+	}
+	// the proxy and the TLS settings are applied to the transport, which
+	// must be an *http.Transport
+	transport := c.Transport
+	if transport == nil && c.HTTPClient != nil {
+		transport = c.HTTPClient.Transport
+	}
+	if _, ok := transport.(*http.Transport); transport != nil && !ok {
+		return ErrUnsupportedSetting
+	}
+	return nil
+}
+
+// fills the zero values with the defaults
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if c.Timeout <= 0 {
+		c.Timeout = defaults.Timeout
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = defaults.UserAgent
+	}
+	return c
+}
@@ -0,0 +1,169 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+)
+
+const (
+	ENV_API_KEY  = "ASAAS_API_KEY"
+	ENV_BASE_URL = "ASAAS_BASE_URL"
+)
+
+var ErrMissingCredentialProvider = errors.New("missing credential provider")
+
+// CredentialProvider supplies the credential used on every request, which
+// allows keys to be rotated without rebuilding the client
+type CredentialProvider interface {
+	Credential(ctx context.Context) (*model.Credential, error)
+}
+
+// CredentialProviderFunc adapts a callback, like a secrets manager lookup, to a CredentialProvider
+type CredentialProviderFunc func(ctx context.Context) (*model.Credential, error)
+
+func (f CredentialProviderFunc) Credential(ctx context.Context) (*model.Credential, error) {
+	return f(ctx)
+}
+
+type staticCredentialProvider struct {
+	credential *model.Credential
+}
+
+func (p *staticCredentialProvider) Credential(ctx context.Context) (*model.Credential, error) {
+	return p.credential, nil
+}
+
+// returns a provider that always supplies the given credential
+func NewStaticCredentialProvider(credential *model.Credential) (CredentialProvider, error) {
+	if credential == nil {
+		return nil, ErrMissingAutenticationData
+	}
+	if err := credential.Validate(); err != nil {
+		return nil, err
+	}
+	return &staticCredentialProvider{credential: credential}, nil
+}
+
+// returns a provider with the credential read from the JSON in reader
+func NewReaderCredentialProvider(reader io.Reader) (CredentialProvider, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	credential := model.NewCredential()
+	if err := credential.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return &staticCredentialProvider{credential: credential}, nil
+}
+
+// returns a provider with the credential read once from the JSON file in path
+func NewFileCredentialProvider(path string) (CredentialProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return NewReaderCredentialProvider(file)
+}
+
+type envCredentialProvider struct {
+	keyVariable  string
+	linkVariable string
+}
+
+// the link is optional, since deployments usually pick the API with
+// WithBaseURL or WithEnvironment and inject only the key
+func (p *envCredentialProvider) Credential(ctx context.Context) (*model.Credential, error) {
+	credential := model.NewCredential().
+		SetAccessToken(os.Getenv(p.keyVariable)).
+		SetLink(os.Getenv(p.linkVariable))
+	if credential.AccessToken == "" {
+		return nil, model.ErrAccessTokenIsRequired
+	}
+	return credential, nil
+}
+
+// returns a provider that reads ASAAS_API_KEY and, if set, ASAAS_BASE_URL from the environment
+func NewEnvCredentialProvider() CredentialProvider {
+	return NewEnvCredentialProviderWithNames(ENV_API_KEY, ENV_BASE_URL)
+}
+
+// returns a provider that reads the API key and the link from the given environment variables
+func NewEnvCredentialProviderWithNames(keyVariable string, linkVariable string) CredentialProvider {
+	return &envCredentialProvider{
+		keyVariable:  keyVariable,
+		linkVariable: linkVariable,
+	}
+}
+
+// WatchedFileCredentialProvider reads the credential from a JSON file and
+// reloads it when the file changes, checking at most once per interval. If
+// a reload fails, the last valid credential keeps being used.
+type WatchedFileCredentialProvider struct {
+	mutex      sync.Mutex
+	path       string
+	interval   time.Duration
+	credential *model.Credential
+	modTime    time.Time
+	size       int64
+	checked    time.Time
+	lastError  error
+}
+
+// creates a watched provider, failing if the file can't be read now
+func NewWatchedFileCredentialProvider(path string, interval time.Duration) (*WatchedFileCredentialProvider, error) {
+	provider := &WatchedFileCredentialProvider{
+		path:     path,
+		interval: interval,
+	}
+	if err := provider.reload(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+func (p *WatchedFileCredentialProvider) Credential(ctx context.Context) (*model.Credential, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if time.Since(p.checked) >= p.interval {
+		p.lastError = p.reload()
+	}
+	return p.credential, nil
+}
+
+// returns the error of the last reload attempt, if any
+func (p *WatchedFileCredentialProvider) LastError() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.lastError
+}
+
+func (p *WatchedFileCredentialProvider) reload() error {
+	p.checked = time.Now()
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return err
+	}
+	if p.credential != nil && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return nil
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+	credential := model.NewCredential()
+	if err := credential.Unmarshal(data); err != nil {
+		return err
+	}
+	p.credential = credential
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return nil
+}
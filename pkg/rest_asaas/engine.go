@@ -2,18 +2,28 @@ package rest_asaas
 
 // Import resty into your code and refer it as `resty`.
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
+// Engine sends the HTTP requests. It is safe for concurrent use.
 type Engine struct {
+	mutex  sync.RWMutex
 	http   *resty.Client
 	token  *Token
-	config map[string]interface{}
+	config Config
+	logger Logger
+
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	middlewares []Middleware
 }
 
 func (e *Engine) getHttp() *resty.Client {
@@ -21,6 +31,8 @@ func (e *Engine) getHttp() *resty.Client {
 }
 
 func (e *Engine) getToken() (*Token, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 	if e.token == nil {
 		return nil, errors.New("missing authentication token")
 	}
@@ -36,66 +48,155 @@ func (e *Engine) SetToken(token *Token) error {
 	if !token.IsValid() {
 		return errors.New("token is invalid")
 	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 	e.token = token
 	return nil
 }
 
-// sets variables used in the requests
-func (e *Engine) SetConfig(key string, value string) {
-	e.config[key] = value
+// returns the settings the engine was built with
+func (e *Engine) GetConfig() Config {
+	return e.config
 }
 
-// gets variables used in the requests
-func (e *Engine) GetConfig(key string) string {
-	return e.config[key].(string)
+// defines the logger used by the engine. The engine is silent by default.
+func (e *Engine) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = newSilentLogger()
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.logger = logger
 }
 
-// sets variables used in the requests
-func (e *Engine) GetConfigData() map[string]interface{} {
-	return e.config
+// defines how transient failures are retried
+func (e *Engine) SetRetryPolicy(policy RetryPolicy) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.retryPolicy = policy
+}
+
+// defines the limiter that throttles requests before they are sent. The
+// limiter may be shared with other engines that use the same API key.
+func (e *Engine) SetRateLimiter(limiter *RateLimiter) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.rateLimiter = limiter
+}
+
+// adds middlewares to the engine, after the ones already added
+func (e *Engine) Use(middlewares ...Middleware) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.middlewares = append(e.middlewares, middlewares...)
+}
+
+// returns the settings used by a request, so they can't change while it runs
+func (e *Engine) getSettings() (Logger, RetryPolicy, *RateLimiter, []Middleware) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	return e.logger, e.retryPolicy, e.rateLimiter, e.middlewares
 }
 
 // posts request to the given link, without token and specific header
-func (e *Engine) PostWithHeaderNoAuth(payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
-	resp, err := e.getHttp().R().SetBody(payload).SetHeaders(header).Post(link)
-	if err != nil {
-		return nil, err
-	}
-	resp.Time()
-	return &Response{
-		code: resp.StatusCode(),
-		raw:  resp.String(),
-	}, nil
+func (e *Engine) PostWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
+	return e.send(ctx, &HTTPRequest{Method: http.MethodPost, URL: link, Header: header, Body: payload})
 }
 
 // gets request to the given link, without token and specific header
-func (e *Engine) GetWithHeaderNoAuth(payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
-	data := e.preparePayload(payload)
-	resp, err := e.getHttp().R().SetQueryParams(data).SetHeaders(header).Get(link)
-	if err != nil {
-		return nil, err
-	}
-	resp.Time()
-	return &Response{
-		code: resp.StatusCode(),
-		raw:  resp.String(),
-	}, nil
+func (e *Engine) GetWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
+	return e.send(ctx, &HTTPRequest{Method: http.MethodGet, URL: link, Header: header, Query: preparePayload(payload)})
+}
+
+// puts request to the given link, without token and specific header
+func (e *Engine) PutWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
+	return e.send(ctx, &HTTPRequest{Method: http.MethodPut, URL: link, Header: header, Body: payload})
+}
+
+// patches request to the given link, without token and specific header
+func (e *Engine) PatchWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error) {
+	return e.send(ctx, &HTTPRequest{Method: http.MethodPatch, URL: link, Header: header, Body: payload})
 }
 
 // deletes request to the given link, using the defined token and specific header without authentication
-func (e *Engine) DeleteWithHeaderNoAuth(link string, header map[string]string) (IResponse, error) {
-	resp, err := e.getHttp().R().SetHeaders(header).Delete(link)
+func (e *Engine) DeleteWithHeaderNoAuth(ctx context.Context, link string, header map[string]string) (IResponse, error) {
+	return e.send(ctx, &HTTPRequest{Method: http.MethodDelete, URL: link, Header: header})
+}
+
+// runs the request through the middlewares and the retry loop
+func (e *Engine) send(ctx context.Context, request *HTTPRequest) (IResponse, error) {
+	request.Header = copyHeader(request.Header)
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		request.Header[IDEMPOTENCY_KEY_HEADER] = key
+	}
+	logger, policy, rateLimiter, middlewares := e.getSettings()
+	handler := chain(func(ctx context.Context, request *HTTPRequest) (*HTTPResponse, error) {
+		return e.execute(ctx, request, logger, policy, rateLimiter)
+	}, middlewares)
+	response, err := handler(ctx, request)
 	if err != nil {
 		return nil, err
 	}
-	resp.Time()
 	return &Response{
-		code: resp.StatusCode(),
-		raw:  resp.String(),
+		code:    response.StatusCode,
+		raw:     response.Body,
+		header:  response.Header,
+		retries: response.Retries,
 	}, nil
 }
 
-func (e *Engine) preparePayload(payload map[string]interface{}) map[string]string {
+// executes the request, retrying transient failures according to the retry policy
+func (e *Engine) execute(ctx context.Context, request *HTTPRequest, logger Logger, policy RetryPolicy, rateLimiter *RateLimiter) (*HTTPResponse, error) {
+	attempts := policy.attemptsFor(request.Method, request.Header)
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		if rateLimiter != nil {
+			if err := rateLimiter.Wait(ctx, request.Header["access_token"], EndpointFamily(request.URL)); err != nil {
+				return nil, err
+			}
+		}
+		httpRequest := e.getHttp().R().SetContext(ctx).SetHeaders(request.Header)
+		if request.Query != nil {
+			httpRequest.SetQueryParams(request.Query)
+		}
+		if request.Body != nil {
+			httpRequest.SetBody(request.Body)
+		}
+		resp, err := httpRequest.Execute(request.Method, request.URL)
+		if attempt < attempts && policy.shouldRetry(ctx, resp, err) {
+			delay := policy.delay(attempt, resp)
+			logger.WarnContext(ctx, "asaas http request will be retried", "method", request.Method, "link", request.URL, "attempt", attempt, "delay", delay, "error", err)
+			if err := sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "asaas http request failed", "method", request.Method, "link", request.URL, "attempt", attempt, "error", err)
+			return nil, err
+		}
+		duration := time.Since(start)
+		logger.DebugContext(ctx, "asaas http request", "method", request.Method, "link", request.URL, "code", resp.StatusCode(), "duration", duration, "retries", attempt-1)
+		return &HTTPResponse{
+			StatusCode: resp.StatusCode(),
+			Header:     resp.Header(),
+			Body:       resp.String(),
+			Retries:    attempt - 1,
+			Duration:   duration,
+		}, nil
+	}
+}
+
+func copyHeader(header map[string]string) map[string]string {
+	result := make(map[string]string, len(header)+1)
+	for key, value := range header {
+		result[key] = value
+	}
+	return result
+}
+
+// turns the payload into query parameters
+func preparePayload(payload map[string]interface{}) map[string]string {
 	result := map[string]string{}
 	for k, v := range payload {
 		switch t := v.(type) {
@@ -119,21 +220,50 @@ func (e *Engine) NeedAutenticate() bool {
 	return err != nil
 }
 
-// gets a Rest struct with the given config
-// if InsecureSkipVerify is set to true, the client will skip the verification of the server's certificate
-func NewEngine(config map[string]interface{}) *Engine {
+// copies the caller's client, and its transport when it is an
+// *http.Transport, so the timeout, proxy and TLS settings of the engine never
+// change a client shared with the rest of the program, like http.DefaultClient
+func copyHTTPClient(client *http.Client) *http.Client {
+	copied := *client
+	if transport, ok := copied.Transport.(*http.Transport); ok {
+		copied.Transport = transport.Clone()
+	}
+	if copied.Transport == nil {
+		copied.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	return &copied
+}
+
+// creates an engine with the given config. Certificates are verified
+// unless InsecureSkipVerify is explicitly set. The config is not validated:
+// settings refused by Config.Validate are ignored by the HTTP client.
+func NewEngine(config Config) *Engine {
+	config = config.withDefaults()
 	client := resty.New()
-	if config["InsecureSkipVerify"] != nil && config["InsecureSkipVerify"].(bool) {
-		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: config["InsecureSkipVerify"].(bool)})
+	if config.HTTPClient != nil {
+		client = resty.NewWithClient(copyHTTPClient(config.HTTPClient))
+	}
+	if config.Transport != nil {
+		client.SetTransport(config.Transport)
+	}
+	if config.InsecureSkipVerify {
+		client.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	}
+	if config.Proxy != "" {
+		client.SetProxy(config.Proxy)
 	}
 	engine := &Engine{
 		http:   client,
 		config: config,
 		token:  &Token{},
+		logger: newSilentLogger(),
+
+		retryPolicy: DefaultRetryPolicy(),
 	}
 	engine.http.SetHeaders(map[string]string{
 		"Content-Type": "application/json",
+		"User-Agent":   config.UserAgent,
 	})
-	engine.getHttp().SetTimeout(1 * time.Minute)
+	engine.getHttp().SetTimeout(config.Timeout)
 	return engine
 }
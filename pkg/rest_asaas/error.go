@@ -3,6 +3,7 @@ package rest_asaas
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 )
 
 type Error struct {
@@ -48,3 +49,67 @@ func NewErrorResponse(data []byte) (*ErrorResponse, error) {
 	}
 	return response, nil
 }
+
+// APIError is returned whenever Asaas answers a request with a non-success
+// status. It keeps the HTTP status, the error codes sent by Asaas, the raw
+// body and the endpoint called, and unwraps to the sentinel error of the
+// operation, so callers can use both errors.As and errors.Is.
+type APIError struct {
+	StatusCode int
+	Errors     []Error
+	Raw        string
+	Endpoint   string
+	Retries    int
+
+	sentinel error
+}
+
+func (e *APIError) Error() string {
+	result := fmt.Sprintf("asaas: %s returned status %d", e.Endpoint, e.StatusCode)
+	if e.sentinel != nil {
+		result = e.sentinel.Error() + ": " + result
+	}
+	for _, err := range e.Errors {
+		result += "; " + err.Code + ": " + err.Description
+	}
+	return result
+}
+
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
+
+// HasCode reports whether Asaas returned the given error code, like invalid_cpfCnpj
+func (e *APIError) HasCode(code string) bool {
+	for _, err := range e.Errors {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Codes returns the error codes returned by Asaas
+func (e *APIError) Codes() []string {
+	result := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		result = append(result, err.Code)
+	}
+	return result
+}
+
+// builds an APIError from the response. A body that can't be decoded still
+// produces an APIError, only without error codes.
+func newAPIError(endpoint string, result IResponse, sentinel error) *APIError {
+	apiError := &APIError{
+		StatusCode: result.GetCode(),
+		Raw:        result.GetRaw(),
+		Endpoint:   endpoint,
+		Retries:    result.GetRetries(),
+		sentinel:   sentinel,
+	}
+	if errResponse, err := NewErrorResponse([]byte(result.GetRaw())); err == nil {
+		apiError.Errors = errResponse.Errors
+	}
+	return apiError
+}
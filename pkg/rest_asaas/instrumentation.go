@@ -0,0 +1,65 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/telemetry"
+)
+
+// defines the tracer that receives a span per operation. Nothing is traced by default.
+func (r *Rest) SetTracer(tracer telemetry.Tracer) {
+	if tracer == nil {
+		tracer = telemetry.NewNoopTracer()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.tracer = tracer
+}
+
+// defines the meter that receives the latency of each operation. Nothing is measured by default.
+func (r *Rest) SetMeter(meter telemetry.Meter) {
+	if meter == nil {
+		meter = telemetry.NewNoopMeter()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.meter = meter
+}
+
+func (r *Rest) getTelemetry() (telemetry.Tracer, telemetry.Meter) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.tracer, r.meter
+}
+
+func (r *Rest) startOperation(ctx context.Context, operation string) (context.Context, telemetry.Span, time.Time) {
+	tracer, _ := r.getTelemetry()
+	ctx, span := tracer.Start(ctx, "asaas."+operation)
+	span.SetAttribute(telemetry.ATTRIBUTE_OPERATION, operation)
+	return ctx, span, time.Now()
+}
+
+// records the status code, Asaas error codes, retries and latency of the operation
+func (r *Rest) endOperation(ctx context.Context, operation string, span telemetry.Span, start time.Time, result IResponse, err error) {
+	_, meter := r.getTelemetry()
+	attributes := map[string]any{
+		telemetry.ATTRIBUTE_OPERATION: operation,
+	}
+	if result != nil {
+		attributes[telemetry.ATTRIBUTE_STATUS_CODE] = result.GetCode()
+		attributes[telemetry.ATTRIBUTE_RETRIES] = result.GetRetries()
+		span.SetAttribute(telemetry.ATTRIBUTE_STATUS_CODE, result.GetCode())
+		span.SetAttribute(telemetry.ATTRIBUTE_RETRIES, result.GetRetries())
+	}
+	var apiError *APIError
+	if errors.As(err, &apiError) && len(apiError.Errors) > 0 {
+		span.SetAttribute(telemetry.ATTRIBUTE_ERROR_CODES, apiError.Codes())
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+	meter.RecordDuration(ctx, telemetry.METRIC_DURATION, time.Since(start), attributes)
+}
@@ -0,0 +1,20 @@
+package rest_asaas
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger is the logging interface used by Rest and Engine. It is satisfied
+// by *slog.Logger, so any slog handler can be plugged in.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+// returns a logger that discards everything, used unless one is configured
+func newSilentLogger() Logger {
+	return slog.New(slog.DiscardHandler)
+}
@@ -0,0 +1,66 @@
+package rest_asaas
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HTTPRequest is the request seen by middlewares, which may change it
+type HTTPRequest struct {
+	Method string
+	URL    string
+	Header map[string]string
+	Query  map[string]string
+	Body   map[string]interface{}
+}
+
+// HTTPResponse is the response seen by middlewares
+type HTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+	Retries    int
+	Duration   time.Duration
+}
+
+// Handler sends a request and returns its response
+type Handler func(ctx context.Context, request *HTTPRequest) (*HTTPResponse, error)
+
+// Middleware wraps a handler. Middlewares run in the order they are added to
+// the engine: the first one sees the request first and the response last. A
+// middleware may answer without calling next, which is how test fakes work.
+type Middleware func(next Handler) Handler
+
+// returns a middleware that runs hook before the request is sent. A hook
+// error aborts the request.
+func BeforeRequest(hook func(ctx context.Context, request *HTTPRequest) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request *HTTPRequest) (*HTTPResponse, error) {
+			if err := hook(ctx, request); err != nil {
+				return nil, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// returns a middleware that runs hook after the response arrives, or after
+// the request fails, in which case response is nil
+func AfterResponse(hook func(ctx context.Context, request *HTTPRequest, response *HTTPResponse, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request *HTTPRequest) (*HTTPResponse, error) {
+			response, err := next(ctx, request)
+			hook(ctx, request, response, err)
+			return response, err
+		}
+	}
+}
+
+// composes the middlewares around the handler, the first being the outermost
+func chain(handler Handler, middlewares []Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,98 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+)
+
+var (
+	ErrNotificationListFailed   = errors.New("notification list failed")
+	ErrNotificationUpdateFailed = errors.New("notification update failed")
+	ErrNotificationNotFound     = errors.New("notification not found")
+)
+
+// lists the first page of the notification settings of the customer. Some
+// events have more than one notification, each with its schedule offset.
+func (r *Rest) ListCustomerNotifications(ctx context.Context, customerID string) (*model.NotificationList, error) {
+	return r.listCustomerNotifications(ctx, customerID, 0)
+}
+
+// iterates over every notification setting of the customer, fetching pages lazily
+func (r *Rest) CustomerNotifications(ctx context.Context, customerID string) iter.Seq2[model.Notification, error] {
+	return paginate(ctx, 0, func(ctx context.Context, offset int) (*model.List[model.Notification], error) {
+		return r.listCustomerNotifications(ctx, customerID, offset)
+	})
+}
+
+func (r *Rest) listCustomerNotifications(ctx context.Context, customerID string, offset int) (*model.NotificationList, error) {
+	if customerID == "" {
+		return nil, model.ErrCustomerIDIsRequired
+	}
+	query := map[string]interface{}{}
+	if offset > 0 {
+		query["offset"] = offset
+	}
+	return do[model.NotificationList](ctx, r, request[noBody]{
+		operation: "ListCustomerNotifications",
+		method:    http.MethodGet,
+		path:      "/v3/customers/" + url.PathEscape(customerID) + "/notifications",
+		query:     query,
+		failure:   ErrNotificationListFailed,
+		notFound:  ErrCustomerNotFound,
+	})
+}
+
+func (r *Rest) UpdateNotification(ctx context.Context, notification *model.Notification) (*model.Notification, error) {
+	if err := notification.Validate(); err != nil {
+		return nil, err
+	}
+	return do[model.Notification](ctx, r, request[*model.Notification]{
+		operation: "UpdateNotification",
+		method:    http.MethodPut,
+		path:      "/v3/notifications/" + url.PathEscape(notification.ID),
+		body:      notification,
+		failure:   ErrNotificationUpdateFailed,
+		notFound:  ErrNotificationNotFound,
+	})
+}
+
+// updates several notifications of the same customer in a single request
+func (r *Rest) UpdateNotifications(ctx context.Context, batch *model.NotificationBatch) ([]model.Notification, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	result, err := do[model.NotificationBatch](ctx, r, request[*model.NotificationBatch]{
+		operation: "UpdateNotifications",
+		method:    http.MethodPut,
+		path:      "/v3/notifications/batch",
+		body:      batch,
+		failure:   ErrNotificationUpdateFailed,
+		notFound:  ErrCustomerNotFound,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Notifications, nil
+}
+
+// applies the profile to the notifications of the customer, usually right
+// after it is created, and returns the notifications that changed
+func (r *Rest) ApplyNotificationProfile(ctx context.Context, customerID string, profile *model.NotificationProfile) ([]model.Notification, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	notifications, err := ListAll(r.CustomerNotifications(ctx, customerID), 0)
+	if err != nil {
+		return nil, err
+	}
+	changed := profile.Apply(notifications)
+	if len(changed) == 0 {
+		return changed, nil
+	}
+	return r.UpdateNotifications(ctx, model.NewNotificationBatch(customerID).Add(changed...))
+}
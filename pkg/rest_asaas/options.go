@@ -0,0 +1,192 @@
+package rest_asaas
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/telemetry"
+)
+
+type clientOptions struct {
+	config         Config
+	baseLink       string
+	environment    Environment
+	credentials    CredentialProvider
+	credentialFile string
+	logger         Logger
+	redactor       *Redactor
+	retryPolicy    *RetryPolicy
+	rateLimiter    *RateLimiter
+	middlewares    []Middleware
+	tracer         telemetry.Tracer
+	meter          telemetry.Meter
+}
+
+// Option configures a client built by NewClient
+type Option func(options *clientOptions)
+
+// disables certificate verification. Only meant for local test servers.
+func WithInsecureSkipVerify() Option {
+	return func(options *clientOptions) {
+		options.config.InsecureSkipVerify = true
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.config.Timeout = timeout
+	}
+}
+
+// uses the given client for every request
+func WithHTTPClient(client *http.Client) Option {
+	return func(options *clientOptions) {
+		options.config.HTTPClient = client
+	}
+}
+
+// uses the given transport for every request
+func WithTransport(transport http.RoundTripper) Option {
+	return func(options *clientOptions) {
+		options.config.Transport = transport
+	}
+}
+
+// sends every request through the given proxy URL
+func WithProxy(proxy string) Option {
+	return func(options *clientOptions) {
+		options.config.Proxy = proxy
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(options *clientOptions) {
+		options.config.UserAgent = userAgent
+	}
+}
+
+// overrides the link supplied by the credential and the environment
+func WithBaseURL(baseURL string) Option {
+	return func(options *clientOptions) {
+		options.baseLink = baseURL
+	}
+}
+
+// sends requests to the sandbox or production API, overriding the credential link
+func WithEnvironment(environment Environment) Option {
+	return func(options *clientOptions) {
+		options.environment = environment
+	}
+}
+
+func WithCredentialProvider(provider CredentialProvider) Option {
+	return func(options *clientOptions) {
+		options.credentials = provider
+	}
+}
+
+// reads the credential from the JSON file in path
+func WithCredentialFile(path string) Option {
+	return func(options *clientOptions) {
+		options.credentialFile = path
+	}
+}
+
+func WithLogger(logger Logger) Option {
+	return func(options *clientOptions) {
+		options.logger = logger
+	}
+}
+
+func WithRedactor(redactor *Redactor) Option {
+	return func(options *clientOptions) {
+		options.redactor = redactor
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(options *clientOptions) {
+		options.retryPolicy = &policy
+	}
+}
+
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(options *clientOptions) {
+		options.rateLimiter = limiter
+	}
+}
+
+// adds middlewares to the engine, in the given order
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(options *clientOptions) {
+		options.middlewares = append(options.middlewares, middlewares...)
+	}
+}
+
+func WithTracer(tracer telemetry.Tracer) Option {
+	return func(options *clientOptions) {
+		options.tracer = tracer
+	}
+}
+
+func WithMeter(meter telemetry.Meter) Option {
+	return func(options *clientOptions) {
+		options.meter = meter
+	}
+}
+
+// creates a client with safe defaults: certificates are verified, requests
+// time out after a minute and the credential is read from the environment,
+// unless options say otherwise
+func NewClient(opts ...Option) (*Rest, error) {
+	options := &clientOptions{
+		config: DefaultConfig(),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := options.config.Validate(); err != nil {
+		return nil, err
+	}
+	credentials := options.credentials
+	if options.credentialFile != "" {
+		provider, err := NewFileCredentialProvider(options.credentialFile)
+		if err != nil {
+			return nil, err
+		}
+		credentials = provider
+	}
+	if credentials == nil {
+		credentials = NewEnvCredentialProvider()
+	}
+	engine := NewEngine(options.config)
+	if options.retryPolicy != nil {
+		engine.SetRetryPolicy(*options.retryPolicy)
+	}
+	engine.SetRateLimiter(options.rateLimiter)
+	engine.Use(options.middlewares...)
+	restEntity, err := NewRestWithProvider(engine, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if options.logger != nil {
+		engine.SetLogger(options.logger)
+		restEntity.SetLogger(options.logger)
+	}
+	if options.redactor != nil {
+		restEntity.SetRedactor(options.redactor)
+	}
+	if options.tracer != nil {
+		restEntity.SetTracer(options.tracer)
+	}
+	if options.meter != nil {
+		restEntity.SetMeter(options.meter)
+	}
+	if link := options.environment.Link(); link != "" {
+		restEntity.SetBaseLink(link)
+	}
+	if options.baseLink != "" {
+		restEntity.SetBaseLink(options.baseLink)
+	}
+	return restEntity, nil
+}
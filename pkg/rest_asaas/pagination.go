@@ -0,0 +1,60 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+)
+
+var ErrTooManyItems = errors.New("list has more items than allowed")
+
+// fetches the page starting at offset
+type pageFetcher[T any] func(ctx context.Context, offset int) (*model.List[T], error)
+
+// iterates over every item of a list endpoint, fetching pages lazily. It
+// stops when Asaas reports no more pages, when the consumer stops or when
+// the context is done, yielding the error of the failed page if any.
+func paginate[T any](ctx context.Context, offset int, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := fetch(ctx, offset)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasMore || len(page.Data) == 0 {
+				return
+			}
+			offset = page.NextOffset()
+		}
+	}
+}
+
+// collects every item of the sequence. If maxItems is positive and the
+// sequence has more than maxItems items, the first maxItems are returned
+// with ErrTooManyItems.
+func ListAll[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	result := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return result, err
+		}
+		if maxItems > 0 && len(result) >= maxItems {
+			return result, ErrTooManyItems
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+)
+
+var (
+	ErrPaymentCreationFailed  = errors.New("payment creation failed")
+	ErrPaymentNotFound        = errors.New("payment not found")
+	ErrPaymentRetrievalFailed = errors.New("payment retrieval failed")
+	ErrPaymentListFailed      = errors.New("payment list failed")
+	ErrPaymentUpdateFailed    = errors.New("payment update failed")
+	ErrPaymentDeletionFailed  = errors.New("payment deletion failed")
+	ErrPaymentRestoreFailed   = errors.New("payment restore failed")
+
+	ErrIdentificationFieldFailed = errors.New("identification field retrieval failed")
+	ErrPixQrCodeFailed           = errors.New("pix qr code retrieval failed")
+)
+
+func (r *Rest) CreatePayment(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+	return do[model.Payment](ctx, r, request[*model.Payment]{
+		operation: "CreatePayment",
+		method:    http.MethodPost,
+		path:      "/v3/payments",
+		body:      payment,
+		failure:   ErrPaymentCreationFailed,
+	})
+}
+
+func (r *Rest) GetPayment(ctx context.Context, paymentID string) (*model.Payment, error) {
+	if paymentID == "" {
+		return nil, model.ErrPaymentIDIsRequired
+	}
+	return do[model.Payment](ctx, r, request[noBody]{
+		operation: "GetPayment",
+		method:    http.MethodGet,
+		path:      "/v3/payments/" + url.PathEscape(paymentID),
+		failure:   ErrPaymentRetrievalFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+}
+
+// changes only the fields defined in the update
+func (r *Rest) UpdatePayment(ctx context.Context, paymentID string, update *model.PaymentUpdate) (*model.Payment, error) {
+	if paymentID == "" {
+		return nil, model.ErrPaymentIDIsRequired
+	}
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	return do[model.Payment](ctx, r, request[*model.PaymentUpdate]{
+		operation: "UpdatePayment",
+		method:    http.MethodPut,
+		path:      "/v3/payments/" + url.PathEscape(paymentID),
+		body:      update,
+		failure:   ErrPaymentUpdateFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+}
+
+// removes the payment. Asaas keeps it, so it can be brought back with RestorePayment.
+func (r *Rest) DeletePayment(ctx context.Context, paymentID string) error {
+	if paymentID == "" {
+		return model.ErrPaymentIDIsRequired
+	}
+	_, err := do[model.Deleted](ctx, r, request[noBody]{
+		operation: "DeletePayment",
+		method:    http.MethodDelete,
+		path:      "/v3/payments/" + url.PathEscape(paymentID),
+		failure:   ErrPaymentDeletionFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+	return err
+}
+
+func (r *Rest) RestorePayment(ctx context.Context, paymentID string) (*model.Payment, error) {
+	if paymentID == "" {
+		return nil, model.ErrPaymentIDIsRequired
+	}
+	return do[model.Payment](ctx, r, request[noBody]{
+		operation: "RestorePayment",
+		method:    http.MethodPost,
+		path:      "/v3/payments/" + url.PathEscape(paymentID) + "/restore",
+		failure:   ErrPaymentRestoreFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+}
+
+// lists a page of the payments matching the filter. A nil filter lists every payment.
+func (r *Rest) ListPayments(ctx context.Context, filter *model.PaymentFilter) (*model.PaymentList, error) {
+	if filter == nil {
+		filter = model.NewPaymentFilter()
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return do[model.PaymentList](ctx, r, request[noBody]{
+		operation: "ListPayments",
+		method:    http.MethodGet,
+		path:      "/v3/payments",
+		query:     filter.ToMap(),
+		failure:   ErrPaymentListFailed,
+	})
+}
+
+// iterates over every payment matching the filter, fetching pages lazily
+func (r *Rest) Payments(ctx context.Context, filter *model.PaymentFilter) iter.Seq2[model.Payment, error] {
+	if filter == nil {
+		filter = model.NewPaymentFilter()
+	}
+	return paginate(ctx, filter.Offset, func(ctx context.Context, offset int) (*model.List[model.Payment], error) {
+		return r.ListPayments(ctx, filter.WithOffset(offset))
+	})
+}
+
+// returns every payment matching the filter, failing with ErrTooManyItems
+// when there are more than maxItems
+func (r *Rest) ListAllPayments(ctx context.Context, filter *model.PaymentFilter, maxItems int) ([]model.Payment, error) {
+	return ListAll(r.Payments(ctx, filter), maxItems)
+}
+
+// returns the digitable line and barcode of a boleto payment, after checking
+// their FEBRABAN check digits
+func (r *Rest) GetPaymentIdentificationField(ctx context.Context, paymentID string) (*model.PaymentIdentificationField, error) {
+	if paymentID == "" {
+		return nil, model.ErrPaymentIDIsRequired
+	}
+	return do[model.PaymentIdentificationField](ctx, r, request[noBody]{
+		operation: "GetPaymentIdentificationField",
+		method:    http.MethodGet,
+		path:      "/v3/payments/" + url.PathEscape(paymentID) + "/identificationField",
+		failure:   ErrIdentificationFieldFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+}
+
+// returns the QR code of a PIX payment, with its image decoded and its
+// payload CRC checked
+func (r *Rest) GetPaymentPixQrCode(ctx context.Context, paymentID string) (*model.PixQrCode, error) {
+	if paymentID == "" {
+		return nil, model.ErrPaymentIDIsRequired
+	}
+	return do[model.PixQrCode](ctx, r, request[noBody]{
+		operation: "GetPaymentPixQrCode",
+		method:    http.MethodGet,
+		path:      "/v3/payments/" + url.PathEscape(paymentID) + "/pixQrCode",
+		failure:   ErrPixQrCodeFailed,
+		notFound:  ErrPaymentNotFound,
+	})
+}
@@ -0,0 +1,194 @@
+package rest_asaas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidRateLimit = errors.New("rate limit must have positive rate and burst")
+
+// number of buckets kept before idle ones are first swept
+const minSweepSize = 64
+
+// RateLimit is a token bucket budget: Burst requests at once, refilled at Rate requests per second
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l RateLimit) IsValid() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   RateLimit
+}
+
+// refills the bucket and returns how long until a token is available
+func (b *bucket) wait(now time.Time) time.Duration {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	b.updated = now
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// tells whether the bucket refilled completely, which makes it the same as a new one
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+// RateLimiter is a concurrency-safe token bucket limiter with one budget per
+// API key and another per API key and endpoint family (customers,
+// subscriptions, payments...). A request waits until both budgets have room.
+// The same limiter can be shared by every engine that uses the same key.
+type RateLimiter struct {
+	mutex        sync.Mutex
+	keyLimit     RateLimit
+	familyLimit  RateLimit
+	familyLimits map[string]RateLimit
+	buckets      map[string]*bucket
+	// size of buckets that triggers the next sweep
+	sweepAt int
+}
+
+// creates a limiter with the given budget per API key and no limit per endpoint family
+func NewRateLimiter(keyLimit RateLimit) (*RateLimiter, error) {
+	if !keyLimit.IsValid() {
+		return nil, ErrInvalidRateLimit
+	}
+	return &RateLimiter{
+		keyLimit:     keyLimit,
+		familyLimits: map[string]RateLimit{},
+		buckets:      map[string]*bucket{},
+		sweepAt:      minSweepSize,
+	}, nil
+}
+
+// defines the budget used by endpoint families without a specific limit
+func (l *RateLimiter) SetDefaultFamilyLimit(limit RateLimit) error {
+	if !limit.IsValid() {
+		return ErrInvalidRateLimit
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.familyLimit = limit
+	return nil
+}
+
+// defines the budget of an endpoint family, like "customers"
+func (l *RateLimiter) SetFamilyLimit(family string, limit RateLimit) error {
+	if !limit.IsValid() {
+		return ErrInvalidRateLimit
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.familyLimits[family] = limit
+	return nil
+}
+
+// blocks until the API key and the endpoint family have budget for one
+// request, or until the context is done
+func (l *RateLimiter) Wait(ctx context.Context, apiKey string, family string) error {
+	for {
+		delay := l.reserve(apiKey, family)
+		if delay == 0 {
+			return nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// takes a token from every applicable bucket, or returns how long to wait
+// without taking any
+func (l *RateLimiter) reserve(apiKey string, family string) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	if len(l.buckets) >= l.sweepAt {
+		l.sweep(now)
+	}
+	key := fingerprint(apiKey)
+	buckets := []*bucket{l.getBucket(key, l.keyLimit, now)}
+	if limit, ok := l.familyLimits[family]; ok {
+		buckets = append(buckets, l.getBucket(key+"/"+family, limit, now))
+	} else if l.familyLimit.IsValid() {
+		buckets = append(buckets, l.getBucket(key+"/"+family, l.familyLimit, now))
+	}
+	var delay time.Duration
+	for _, b := range buckets {
+		delay = max(delay, b.wait(now))
+	}
+	if delay > 0 {
+		return delay
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0
+}
+
+// drops the buckets that refilled completely, so keys that are rotated or
+// no longer used don't stay in memory. The next sweep happens when the map
+// doubles, keeping the cost constant per request.
+func (l *RateLimiter) sweep(now time.Time) {
+	for name, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, name)
+		}
+	}
+	l.sweepAt = max(minSweepSize, 2*len(l.buckets))
+}
+
+// returns how many buckets the limiter keeps
+func (l *RateLimiter) Size() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.buckets)
+}
+
+func (l *RateLimiter) getBucket(name string, limit RateLimit, now time.Time) *bucket {
+	b, ok := l.buckets[name]
+	if !ok || b.limit != limit {
+		b = &bucket{
+			tokens:  float64(limit.Burst),
+			updated: now,
+			limit:   limit,
+		}
+		l.buckets[name] = b
+	}
+	return b
+}
+
+// keeps buckets indexed by a hash, so the limiter never holds API keys
+func fingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// returns the endpoint family of a link, like "customers" for /v3/customers/cus_1
+func EndpointFamily(link string) string {
+	path := link
+	if parsed, err := url.Parse(link); err == nil {
+		path = parsed.Path
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == "v3" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return parts[0]
+}
@@ -0,0 +1,150 @@
+package rest_asaas
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+const REDACTED = "[REDACTED]"
+
+// keys redacted by default: credentials, documents, contact and card data
+var defaultRedactedKeys = []string{
+	"access_token",
+	"cpfCnpj",
+	"mobilePhone",
+	"phone",
+	"email",
+	"additionalEmails",
+	"creditCard",
+	"creditCardNumber",
+	"creditCardToken",
+	"creditCardHolderInfo",
+	"ccv",
+	"holderName",
+	"expiryMonth",
+	"expiryYear",
+}
+
+// Redactor masks sensitive values before they reach a logger. Keys are
+// matched case-insensitively at any depth of maps, slices and JSON bodies.
+type Redactor struct {
+	mutex sync.RWMutex
+	keys  map[string]struct{}
+	mask  string
+}
+
+// creates a redactor that masks the given keys
+func NewRedactor(keys ...string) *Redactor {
+	redactor := &Redactor{
+		keys: map[string]struct{}{},
+		mask: REDACTED,
+	}
+	return redactor.AddKeys(keys...)
+}
+
+// creates a redactor with the default rules for credentials and LGPD-protected data
+func NewDefaultRedactor() *Redactor {
+	return NewRedactor(defaultRedactedKeys...)
+}
+
+// adds keys to be masked
+func (r *Redactor) AddKeys(keys ...string) *Redactor {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+	return r
+}
+
+// stops masking the given keys
+func (r *Redactor) RemoveKeys(keys ...string) *Redactor {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, key := range keys {
+		delete(r.keys, strings.ToLower(key))
+	}
+	return r
+}
+
+// defines the text used in place of redacted values
+func (r *Redactor) SetMask(mask string) *Redactor {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.mask = mask
+	return r
+}
+
+func (r *Redactor) IsRedacted(key string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	_, ok := r.keys[strings.ToLower(key)]
+	return ok
+}
+
+// returns a redacted copy of the map, leaving the original untouched
+func (r *Redactor) Map(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	return r.value(data).(map[string]interface{})
+}
+
+// returns a redacted copy of the headers
+func (r *Redactor) Headers(header map[string]string) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, value := range header {
+		if r.IsRedacted(key) {
+			value = r.getMask()
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// redacts a JSON body. Bodies that are not JSON are replaced by the mask,
+// since there is no safe way to tell what they carry.
+func (r *Redactor) JSON(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return raw
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return r.getMask()
+	}
+	result, err := json.Marshal(r.value(data))
+	if err != nil {
+		return r.getMask()
+	}
+	return string(result)
+}
+
+func (r *Redactor) getMask() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.mask
+}
+
+func (r *Redactor) value(value interface{}) interface{} {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(t))
+		for key, item := range t {
+			if r.IsRedacted(key) {
+				result[key] = r.getMask()
+				continue
+			}
+			result[key] = r.value(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(t))
+		for i, item := range t {
+			result[i] = r.value(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
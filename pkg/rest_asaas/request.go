@@ -0,0 +1,131 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var (
+	ErrUnsupportedMethod  = errors.New("unsupported http method")
+	ErrUnexpectedResponse = errors.New("unexpected response body")
+)
+
+// implemented by request bodies sent to Asaas
+type payload interface {
+	ToMap() map[string]interface{}
+}
+
+// implemented by pointers to the structs Asaas answers with
+type decodable[T any] interface {
+	*T
+	Unmarshal(data []byte) error
+}
+
+// body of requests that send none
+type noBody struct{}
+
+func (noBody) ToMap() map[string]interface{} {
+	return nil
+}
+
+// describes a call to the Asaas API
+type request[Req payload] struct {
+	// name of the span and of the latency metric, like CreateCustomer
+	operation string
+	method    string
+	path      string
+	query     map[string]interface{}
+	body      Req
+	// sentinel wrapped by the APIError of failed calls
+	failure error
+	// sentinel used instead of failure when Asaas answers 404
+	notFound error
+}
+
+// runs a call: authenticates, sends it with the credential headers, turns
+// non-2xx answers into an APIError and decodes the body into Resp, inside
+// an instrumented operation
+func do[Resp any, PResp decodable[Resp], Req payload](ctx context.Context, r *Rest, req request[Req]) (*Resp, error) {
+	ctx, span, start := r.startOperation(ctx, req.operation)
+	result, err := r.execute(ctx, req.method, req.path, req.query, req.body.ToMap(), req.failure, req.notFound)
+	response := PResp(new(Resp))
+	if err == nil {
+		if err = response.Unmarshal([]byte(result.GetRaw())); err != nil {
+			// a 2xx answer that can't be decoded is reported as a failure
+			// of the operation, keeping the decoding error
+			err = fmt.Errorf("%w: %w: %w", req.failure, ErrUnexpectedResponse, err)
+			r.logResponse(ctx, result, err)
+		}
+	}
+	r.endOperation(ctx, req.operation, span, start, result, err)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// sends the call and returns the response, which is kept along with the
+// APIError of non-2xx answers
+func (r *Rest) execute(ctx context.Context, method string, path string, query map[string]interface{}, body map[string]interface{}, failure error, notFound error) (IResponse, error) {
+	credential, err := r.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.engine.NeedAutenticate() {
+		return nil, ErrAuthenticationRequired
+	}
+	link := r.getLink(credential, path)
+	header := map[string]string{
+		"access_token": credential.AccessToken,
+		"accept":       "application/json",
+	}
+	if body != nil {
+		header["content-type"] = "application/json"
+		r.logRequest(ctx, link, body)
+	}
+	result, err := r.send(ctx, method, link, query, body, header)
+	if err != nil {
+		r.logResponse(ctx, result, err)
+		return nil, err
+	}
+	if !isSuccess(result.GetCode()) {
+		if notFound != nil && result.GetCode() == http.StatusNotFound {
+			failure = notFound
+		}
+		err := newAPIError(path, result, failure)
+		r.logResponse(ctx, result, err)
+		return result, err
+	}
+	return result, nil
+}
+
+// dispatches the call to the engine method of the verb
+func (r *Rest) send(ctx context.Context, method string, link string, query map[string]interface{}, body map[string]interface{}, header map[string]string) (IResponse, error) {
+	if method != http.MethodGet && len(query) > 0 {
+		values := url.Values{}
+		for key, value := range preparePayload(query) {
+			values.Set(key, value)
+		}
+		link += "?" + values.Encode()
+	}
+	switch method {
+	case http.MethodGet:
+		return r.engine.GetWithHeaderNoAuth(ctx, query, link, header)
+	case http.MethodPost:
+		return r.engine.PostWithHeaderNoAuth(ctx, body, link, header)
+	case http.MethodPut:
+		return r.engine.PutWithHeaderNoAuth(ctx, body, link, header)
+	case http.MethodPatch:
+		return r.engine.PatchWithHeaderNoAuth(ctx, body, link, header)
+	case http.MethodDelete:
+		return r.engine.DeleteWithHeaderNoAuth(ctx, link, header)
+	}
+	return nil, ErrUnsupportedMethod
+}
+
+func isSuccess(code int) bool {
+	return code >= http.StatusOK && code < http.StatusMultipleChoices
+}
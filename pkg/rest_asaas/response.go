@@ -1,8 +1,12 @@
 package rest_asaas
 
+import "net/http"
+
 type Response struct {
-	code int
-	raw  string
+	code    int
+	raw     string
+	header  http.Header
+	retries int
 }
 
 func (r *Response) GetRaw() string {
@@ -12,3 +16,13 @@ func (r *Response) GetRaw() string {
 func (r *Response) GetCode() int {
 	return r.code
 }
+
+// returns a response header
+func (r *Response) GetHeader(key string) string {
+	return r.header.Get(key)
+}
+
+// returns how many times the request was retried before this response
+func (r *Response) GetRetries() int {
+	return r.retries
+}
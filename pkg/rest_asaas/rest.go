@@ -1,13 +1,15 @@
 package rest_asaas
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"iter"
 	"net/http"
-	"os"
+	"net/url"
+	"sync"
 
 	"github.com/pericles-luz/go-asaas/pkg/model"
-	"github.com/pericles-luz/go-base/pkg/utils"
+	"github.com/pericles-luz/go-asaas/pkg/telemetry"
 )
 
 var (
@@ -23,11 +25,18 @@ var (
 	ErrSubscriptionIDIsRequired = errors.New("subscription id is required")
 	ErrCustomerCreationFailed   = errors.New("customer creation failed")
 	ErrCustomerNotFound         = errors.New("customer not found")
+	ErrCustomerRetrievalFailed  = errors.New("customer retrieval failed")
+	ErrCustomerListFailed       = errors.New("customer list failed")
+	ErrCustomerUpdateFailed     = errors.New("customer update failed")
+	ErrCustomerDeletionFailed   = errors.New("customer deletion failed")
+	ErrCustomerRestoreFailed    = errors.New("customer restore failed")
+	ErrSubscriptionNotFound     = errors.New("subscription not found")
 )
 
 type IResponse interface {
 	GetCode() int
 	GetRaw() string
+	GetRetries() int
 }
 
 type IToken interface {
@@ -41,42 +50,69 @@ type IToken interface {
 type IEngine interface {
 	SetToken(token *Token) error
 	NeedAutenticate() bool
-	PostWithHeaderNoAuth(payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
-	GetWithHeaderNoAuth(payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
-	DeleteWithHeaderNoAuth(link string, header map[string]string) (IResponse, error)
+	PostWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
+	GetWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
+	PutWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
+	PatchWithHeaderNoAuth(ctx context.Context, payload map[string]interface{}, link string, header map[string]string) (IResponse, error)
+	DeleteWithHeaderNoAuth(ctx context.Context, link string, header map[string]string) (IResponse, error)
 }
 
+// Rest is the Asaas API client. It is safe for concurrent use, so a single
+// client can be shared by every goroutine of a server.
 type Rest struct {
+	mutex    sync.RWMutex
 	engine   IEngine
 	baseLink string
 
-	credential *model.Credential
+	credentials CredentialProvider
+	credential  *model.Credential
+	logger      Logger
+	redactor    *Redactor
+	tracer      telemetry.Tracer
+	meter       telemetry.Meter
 }
 
+// creates a client with the credential read from the JSON file in credentials
 func NewRest(engine IEngine, credentials string) (*Rest, error) {
-	if engine == nil {
-		return nil, ErrMissingEngine
-	}
-	configJSON, err := os.ReadFile(credentials)
+	provider, err := NewFileCredentialProvider(credentials)
 	if err != nil {
 		return nil, err
 	}
-	credential := model.NewCredential()
-	if err := credential.Unmarshal(configJSON); err != nil {
-		return nil, err
+	return NewRestWithProvider(engine, provider)
+}
+
+// creates a client that asks the provider for the credential before each request
+func NewRestWithProvider(engine IEngine, credentials CredentialProvider) (*Rest, error) {
+	if engine == nil {
+		return nil, ErrMissingEngine
+	}
+	if credentials == nil {
+		return nil, ErrMissingCredentialProvider
 	}
 	return &Rest{
-		engine:     engine,
-		credential: credential,
-		baseLink:   credential.Link,
+		engine:      engine,
+		credentials: credentials,
+		logger:      newSilentLogger(),
+		redactor:    NewDefaultRedactor(),
+		tracer:      telemetry.NewNoopTracer(),
+		meter:       telemetry.NewNoopMeter(),
 	}, nil
 }
 
+// overrides the link supplied by the credential
 func (r *Rest) SetBaseLink(baseLink string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 	r.baseLink = baseLink
 }
 
-func (r *Rest) getLink(link string) string {
+// builds the link of the path, based on the given credential unless the base link was overridden
+func (r *Rest) getLink(credential *model.Credential, link string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.baseLink == "" && credential != nil {
+		return credential.Link + link
+	}
 	return r.baseLink + link
 }
 
@@ -84,240 +120,246 @@ func (r *Rest) SetToken(token *Token) error {
 	return r.engine.SetToken(token)
 }
 
-func (r *Rest) showResponse(result IResponse) {
+// defines the logger used by the client. The client is silent by default.
+func (r *Rest) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = newSilentLogger()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.logger = logger
+}
+
+// defines the rules used to mask sensitive data before logging
+func (r *Rest) SetRedactor(redactor *Redactor) {
+	if redactor == nil {
+		redactor = NewDefaultRedactor()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.redactor = redactor
+}
+
+func (r *Rest) getLogger() (Logger, *Redactor) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.logger, r.redactor
+}
+
+func (r *Rest) logRequest(ctx context.Context, link string, payload map[string]interface{}) {
+	logger, redactor := r.getLogger()
+	logger.DebugContext(ctx, "asaas request", "link", link, "payload", redactor.Map(payload))
+}
+
+func (r *Rest) logResponse(ctx context.Context, result IResponse, err error) {
+	logger, redactor := r.getLogger()
 	if result == nil {
-		fmt.Println("Result is nil")
+		logger.ErrorContext(ctx, "asaas request failed", "error", err)
 		return
 	}
-	fmt.Println("Code: ", result.GetCode())
-	fmt.Println("Raw: ", result.GetRaw())
+	args := []any{"code", result.GetCode(), "raw", redactor.JSON(result.GetRaw())}
+	if err != nil {
+		args = append(args, "error", err)
+	}
+	logger.ErrorContext(ctx, "asaas request failed", args...)
 }
 
-func (r *Rest) Authenticate() error {
-	if r.engine == nil {
-		return ErrMissingEngine
-	}
-	if !r.engine.NeedAutenticate() {
-		return nil
-	}
-	if r.credential == nil {
-		return ErrMissingAutenticationData
-	}
-	if err := r.credential.Validate(); err != nil {
-		return err
-	}
-	token := NewToken(r.credential.AccessToken, 60)
-	return r.SetToken(token)
+// loads the current credential from the provider, renewing the token when
+// it expired or the key was rotated
+func (r *Rest) Authenticate(ctx context.Context) error {
+	_, err := r.authenticate(ctx)
+	return err
 }
 
-func (r *Rest) CreateCustomer(customer *model.Customer) (*model.Customer, error) {
-	if err := r.Authenticate(); err != nil {
+// authenticates and returns the credential to be used by the request, which
+// stays the same even if another goroutine rotates the key meanwhile
+func (r *Rest) authenticate(ctx context.Context) (*model.Credential, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
-	if err := customer.Validate(); err != nil {
-		return nil, err
+	if r.engine == nil {
+		return nil, ErrMissingEngine
 	}
-	if r.engine.NeedAutenticate() {
-		return nil, ErrAuthenticationRequired
+	if r.credentials == nil {
+		return nil, ErrMissingCredentialProvider
 	}
-	fmt.Println("Customer: ", string(utils.MapInterfaceToBytes(customer.ToMap())))
-	fmt.Println("Link: ", r.getLink("/v3/customers"))
-	result, err := r.engine.PostWithHeaderNoAuth(customer.ToMap(), r.getLink("/v3/customers"), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
-		"content-type": "application/json",
-	})
+	credential, err := r.credentials.Credential(ctx)
 	if err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
 		return nil, err
 	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		errResponse, err := NewErrorResponse([]byte(result.GetRaw()))
-		if err != nil {
-			return nil, ErrCustomerCreationFailed
-		}
-		if errResponse.HasErrors() {
-			fmt.Println("Error: ", errResponse.String())
-			return nil, errResponse.Return()
-		}
-		return nil, ErrCustomerCreationFailed
+	if credential == nil {
+		return nil, ErrMissingAutenticationData
 	}
-	customerResponse := model.NewCustomer()
-	if err := customerResponse.Unmarshal([]byte(result.GetRaw())); err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := r.validateCredential(credential); err != nil {
 		return nil, err
 	}
-	return customerResponse, nil
+	rotated := r.credential == nil || r.credential.AccessToken != credential.AccessToken
+	r.credential = credential
+	if !rotated && !r.engine.NeedAutenticate() {
+		return credential, nil
+	}
+	token := NewToken(credential.AccessToken, 60)
+	if err := r.engine.SetToken(token); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// the credential link is only needed when no base link overrides it; must
+// be called with the lock held
+func (r *Rest) validateCredential(credential *model.Credential) error {
+	if r.baseLink == "" {
+		return credential.Validate()
+	}
+	if credential.AccessToken == "" {
+		return model.ErrAccessTokenIsRequired
+	}
+	return nil
 }
 
-func (r *Rest) GetCustomer(customerID string) (*model.Customer, error) {
-	if err := r.Authenticate(); err != nil {
+func (r *Rest) CreateCustomer(ctx context.Context, customer *model.Customer) (*model.Customer, error) {
+	if err := customer.Validate(); err != nil {
 		return nil, err
 	}
+	return do[model.Customer](ctx, r, request[*model.Customer]{
+		operation: "CreateCustomer",
+		method:    http.MethodPost,
+		path:      "/v3/customers",
+		body:      customer,
+		failure:   ErrCustomerCreationFailed,
+	})
+}
+
+func (r *Rest) GetCustomer(ctx context.Context, customerID string) (*model.Customer, error) {
 	if customerID == "" {
 		return nil, model.ErrCustomerIDIsRequired
 	}
-	result, err := r.engine.GetWithHeaderNoAuth(nil, r.getLink("/v3/customers/"+customerID), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
+	return do[model.Customer](ctx, r, request[noBody]{
+		operation: "GetCustomer",
+		method:    http.MethodGet,
+		path:      "/v3/customers/" + url.PathEscape(customerID),
+		failure:   ErrCustomerRetrievalFailed,
+		notFound:  ErrCustomerNotFound,
 	})
-	if err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return nil, err
-	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		errResponse, err := NewErrorResponse([]byte(result.GetRaw()))
-		if err != nil {
-			return nil, ErrCustomerCreationFailed
-		}
-		if errResponse.HasErrors() {
-			fmt.Println("Error: ", errResponse.String())
-			return nil, errResponse.Return()
-		}
-		return nil, ErrCustomerNotFound
+}
+
+// changes only the fields defined in the update
+func (r *Rest) UpdateCustomer(ctx context.Context, customerID string, update *model.CustomerUpdate) (*model.Customer, error) {
+	if customerID == "" {
+		return nil, model.ErrCustomerIDIsRequired
 	}
-	customer := model.NewCustomer()
-	if err := customer.Unmarshal([]byte(result.GetRaw())); err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
+	if err := update.Validate(); err != nil {
 		return nil, err
 	}
-	return customer, nil
+	return do[model.Customer](ctx, r, request[*model.CustomerUpdate]{
+		operation: "UpdateCustomer",
+		method:    http.MethodPut,
+		path:      "/v3/customers/" + url.PathEscape(customerID),
+		body:      update,
+		failure:   ErrCustomerUpdateFailed,
+		notFound:  ErrCustomerNotFound,
+	})
 }
 
-func (r *Rest) ListCustomers(filter map[string]interface{}) (*model.CustomerList, error) {
-	if err := r.Authenticate(); err != nil {
-		return nil, err
-	}
-	result, err := r.engine.GetWithHeaderNoAuth(filter, r.getLink("/v3/customers"), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
+// removes the customer. Asaas keeps it, so it can be brought back with RestoreCustomer.
+func (r *Rest) DeleteCustomer(ctx context.Context, customerID string) error {
+	if customerID == "" {
+		return model.ErrCustomerIDIsRequired
+	}
+	_, err := do[model.Deleted](ctx, r, request[noBody]{
+		operation: "DeleteCustomer",
+		method:    http.MethodDelete,
+		path:      "/v3/customers/" + url.PathEscape(customerID),
+		failure:   ErrCustomerDeletionFailed,
+		notFound:  ErrCustomerNotFound,
 	})
-	if err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return nil, err
+	return err
+}
+
+func (r *Rest) RestoreCustomer(ctx context.Context, customerID string) (*model.Customer, error) {
+	if customerID == "" {
+		return nil, model.ErrCustomerIDIsRequired
 	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		errResponse, err := NewErrorResponse([]byte(result.GetRaw()))
-		if err != nil {
-			return nil, ErrCustomerCreationFailed
-		}
-		if errResponse.HasErrors() {
-			fmt.Println("Error: ", errResponse.String())
-			return nil, errResponse.Return()
-		}
-		return nil, ErrCustomerNotFound
+	return do[model.Customer](ctx, r, request[noBody]{
+		operation: "RestoreCustomer",
+		method:    http.MethodPost,
+		path:      "/v3/customers/" + url.PathEscape(customerID) + "/restore",
+		failure:   ErrCustomerRestoreFailed,
+		notFound:  ErrCustomerNotFound,
+	})
+}
+
+// lists a page of the customers matching the filter. A nil filter lists every customer.
+func (r *Rest) ListCustomers(ctx context.Context, filter *model.CustomerFilter) (*model.CustomerList, error) {
+	if filter == nil {
+		filter = model.NewCustomerFilter()
 	}
-	customers := model.NewCustomerList()
-	if err := customers.Unmarshal([]byte(result.GetRaw())); err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
+	if err := filter.Validate(); err != nil {
 		return nil, err
 	}
-	return customers, nil
+	return do[model.CustomerList](ctx, r, request[noBody]{
+		operation: "ListCustomers",
+		method:    http.MethodGet,
+		path:      "/v3/customers",
+		query:     filter.ToMap(),
+		failure:   ErrCustomerListFailed,
+	})
 }
 
-func (r *Rest) Subscribe(subscription *model.Subscription) (*model.Subscription, error) {
-	if err := r.Authenticate(); err != nil {
-		return nil, err
+// iterates over every customer matching the filter, fetching pages lazily
+func (r *Rest) Customers(ctx context.Context, filter *model.CustomerFilter) iter.Seq2[model.Customer, error] {
+	if filter == nil {
+		filter = model.NewCustomerFilter()
 	}
+	return paginate(ctx, filter.Offset, func(ctx context.Context, offset int) (*model.List[model.Customer], error) {
+		return r.ListCustomers(ctx, filter.WithOffset(offset))
+	})
+}
+
+// returns every customer matching the filter, failing with ErrTooManyItems
+// when there are more than maxItems
+func (r *Rest) ListAllCustomers(ctx context.Context, filter *model.CustomerFilter, maxItems int) ([]model.Customer, error) {
+	return ListAll(r.Customers(ctx, filter), maxItems)
+}
+
+func (r *Rest) Subscribe(ctx context.Context, subscription *model.Subscription) (*model.Subscription, error) {
 	if err := subscription.Validate(); err != nil {
 		return nil, err
 	}
-	if r.engine.NeedAutenticate() {
-		return nil, ErrAuthenticationRequired
-	}
-	fmt.Println("Subscription: ", string(utils.MapInterfaceToBytes(subscription.ToMap())))
-	fmt.Println("Link: ", r.getLink("/v3/subscriptions"))
-	result, err := r.engine.PostWithHeaderNoAuth(subscription.ToMap(), r.getLink("/v3/subscriptions"), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
-		"content-type": "application/json",
+	return do[model.Subscription](ctx, r, request[*model.Subscription]{
+		operation: "Subscribe",
+		method:    http.MethodPost,
+		path:      "/v3/subscriptions",
+		body:      subscription,
+		failure:   ErrSubscriptionFailed,
 	})
-	if err != nil {
-		return nil, err
-	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		errResponse, err := NewErrorResponse([]byte(result.GetRaw()))
-		if err != nil {
-			return nil, ErrCustomerCreationFailed
-		}
-		if errResponse.HasErrors() {
-			fmt.Println("Error: ", errResponse.String())
-			return nil, errResponse.Return()
-		}
-		return nil, ErrSubscriptionFailed
-	}
-	subscriptionResponse := model.NewSubscription()
-	if err := subscriptionResponse.Unmarshal([]byte(result.GetRaw())); err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return nil, err
-	}
-	return subscriptionResponse, nil
 }
 
-func (r *Rest) GetSubscription(subscriptionID string) (*model.Subscription, error) {
-	if err := r.Authenticate(); err != nil {
-		return nil, err
-	}
+func (r *Rest) GetSubscription(ctx context.Context, subscriptionID string) (*model.Subscription, error) {
 	if subscriptionID == "" {
 		return nil, ErrSubscriptionIDIsRequired
 	}
-	result, err := r.engine.GetWithHeaderNoAuth(nil, r.getLink("/v3/subscriptions/"+subscriptionID), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
+	return do[model.Subscription](ctx, r, request[noBody]{
+		operation: "GetSubscription",
+		method:    http.MethodGet,
+		path:      "/v3/subscriptions/" + url.PathEscape(subscriptionID),
+		failure:   ErrSubscriptionFailed,
+		notFound:  ErrSubscriptionNotFound,
 	})
-	if err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return nil, err
-	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		errResponse, err := NewErrorResponse([]byte(result.GetRaw()))
-		if err != nil {
-			return nil, ErrCustomerCreationFailed
-		}
-		if errResponse.HasErrors() {
-			fmt.Println("Error: ", errResponse.String())
-			return nil, errResponse.Return()
-		}
-		return nil, ErrSubscriptionFailed
-	}
-	subscription := model.NewSubscription()
-	if err := subscription.Unmarshal([]byte(result.GetRaw())); err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return nil, err
-	}
-	return subscription, nil
 }
 
-func (r *Rest) Unsubscribe(subscriptionID string) error {
-	if err := r.Authenticate(); err != nil {
-		return err
-	}
-	result, err := r.engine.DeleteWithHeaderNoAuth(r.getLink("/v3/subscriptions/"+subscriptionID), map[string]string{
-		"access_token": r.credential.AccessToken,
-		"accept":       "application/json",
+func (r *Rest) Unsubscribe(ctx context.Context, subscriptionID string) error {
+	if subscriptionID == "" {
+		return ErrSubscriptionIDIsRequired
+	}
+	_, err := do[model.Deleted](ctx, r, request[noBody]{
+		operation: "Unsubscribe",
+		method:    http.MethodDelete,
+		path:      "/v3/subscriptions/" + url.PathEscape(subscriptionID),
+		failure:   ErrSubscriptionFailed,
+		notFound:  ErrSubscriptionNotFound,
 	})
-	if err != nil {
-		r.showResponse(result)
-		fmt.Println("Error: ", err)
-		return err
-	}
-	if result.GetCode() != http.StatusOK {
-		r.showResponse(result)
-		return ErrSubscriptionFailed
-	}
-	return nil
+	return err
 }
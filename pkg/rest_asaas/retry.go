@@ -0,0 +1,138 @@
+package rest_asaas
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+
+type idempotencyKeyContext struct{}
+
+// RetryPolicy defines how the engine retries transient failures. Only
+// idempotent methods are retried, unless the request carries an
+// idempotency key or RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxAttempts        int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	Jitter             float64 // fraction of the delay randomly subtracted, between 0 and 1
+	RetryableStatus    map[int]bool
+	RetryableMethods   map[string]bool
+	RetryNonIdempotent bool
+}
+
+// retries GETs and DELETEs up to 3 times on 429 and 5xx responses
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+		RetryableMethods: map[string]bool{
+			http.MethodGet:    true,
+			http.MethodDelete: true,
+		},
+	}
+}
+
+// sends every request only once
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// returns a context that makes the engine send the given idempotency key,
+// allowing non-idempotent requests to be retried safely
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContext{}).(string)
+	return key
+}
+
+// returns how many attempts the request may take
+func (p RetryPolicy) attemptsFor(method string, header map[string]string) int {
+	if p.MaxAttempts <= 1 {
+		return 1
+	}
+	if p.RetryableMethods[method] || p.RetryNonIdempotent || header[IDEMPOTENCY_KEY_HEADER] != "" {
+		return p.MaxAttempts
+	}
+	return 1
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, resp *resty.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return p.RetryableStatus[resp.StatusCode()]
+}
+
+// computes the wait before the next attempt, honoring Retry-After when
+// present. Both are capped by MaxDelay, so a huge Retry-After can't park the
+// request for hours.
+func (p RetryPolicy) delay(attempt int, resp *resty.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header().Get("Retry-After")); ok {
+			if p.MaxDelay > 0 {
+				delay = min(delay, p.MaxDelay)
+			}
+			return delay
+		}
+	}
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// waits for the given delay or until the context is done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,7 +1,6 @@
 package rest_asaas
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -42,7 +41,6 @@ func (t *Token) GetValidity() string {
 }
 
 func (t *Token) GetKey() string {
-	fmt.Println("Token: ", t.key)
 	return t.key
 }
 
@@ -0,0 +1,93 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+// run with -race to detect unsynchronized access
+func TestRestShouldBeSafeForConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id":"cus_1","name":"John Doe","cpfCnpj":"00000000191","mobilePhone":"31999999999"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"cus_1","name":"John Doe","cpfCnpj":"00000000191","email":"john@doe.com"}`))
+	}))
+	defer server.Close()
+	calls := &atomic.Int64{}
+	provider := rest_asaas.CredentialProviderFunc(func(ctx context.Context) (*model.Credential, error) {
+		// rotates the key on every tenth call
+		key := fmt.Sprintf("$aact_%d", calls.Add(1)/10)
+		return model.NewCredential().SetAccessToken(key).SetLink(server.URL), nil
+	})
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	restEntity, err := rest_asaas.NewRestWithProvider(engine, provider)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := range 50 {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			_, err := restEntity.GetCustomer(context.Background(), "cus_1")
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			customer := model.NewCustomer().
+				SetName("John Doe").
+				SetCpfCnpj("00000000191").
+				SetMobilePhone("31999999999")
+			_, err := restEntity.CreateCustomer(context.Background(), customer)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			restEntity.SetLogger(slog.New(slog.DiscardHandler))
+			restEntity.SetRedactor(rest_asaas.NewDefaultRedactor())
+			engine.SetLogger(nil)
+			errs <- nil
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- engine.SetToken(rest_asaas.NewToken(fmt.Sprintf("$aact_token_%d", i), 60))
+			_ = engine.NeedAutenticate()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestRateLimiterShouldBeSafeForConcurrentUse(t *testing.T) {
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 10000, Burst: 100})
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- limiter.Wait(context.Background(), "key", fmt.Sprintf("family_%d", i%3))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
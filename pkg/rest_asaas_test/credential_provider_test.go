@@ -0,0 +1,120 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvCredentialProviderShouldReadEnvironment(t *testing.T) {
+	t.Setenv(rest_asaas.ENV_API_KEY, "$aact_env")
+	t.Setenv(rest_asaas.ENV_BASE_URL, "https://api-sandbox.asaas.com")
+	credential, err := rest_asaas.NewEnvCredentialProvider().Credential(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "$aact_env", credential.AccessToken)
+	require.Equal(t, "https://api-sandbox.asaas.com", credential.Link)
+}
+
+func TestEnvCredentialProviderShouldFailWithoutKey(t *testing.T) {
+	t.Setenv("CUSTOM_KEY", "")
+	t.Setenv("CUSTOM_LINK", "https://api-sandbox.asaas.com")
+	_, err := rest_asaas.NewEnvCredentialProviderWithNames("CUSTOM_KEY", "CUSTOM_LINK").Credential(context.Background())
+	require.ErrorIs(t, err, model.ErrAccessTokenIsRequired)
+}
+
+func TestEnvCredentialProviderShouldNotRequireLink(t *testing.T) {
+	server := asaastest.NewServer()
+	defer server.Close()
+	t.Setenv(rest_asaas.ENV_API_KEY, asaastest.API_KEY)
+	t.Setenv(rest_asaas.ENV_BASE_URL, "")
+	customerID := seedCustomer(server, "John Doe")
+	restEntity, err := rest_asaas.NewClient(rest_asaas.WithBaseURL(server.URL()), rest_asaas.WithRetryPolicy(rest_asaas.NoRetryPolicy()))
+	require.NoError(t, err)
+	customer, err := restEntity.GetCustomer(context.Background(), customerID)
+	require.NoError(t, err, "the base URL should replace the missing link")
+	require.Equal(t, customerID, customer.ID)
+	restEntity, err = rest_asaas.NewClient(rest_asaas.WithRetryPolicy(rest_asaas.NoRetryPolicy()))
+	require.NoError(t, err)
+	_, err = restEntity.GetCustomer(context.Background(), customerID)
+	require.ErrorIs(t, err, model.ErrLinkIsRequired, "the link is needed when nothing replaces it")
+}
+
+func TestReaderCredentialProviderShouldReadJSON(t *testing.T) {
+	provider, err := rest_asaas.NewReaderCredentialProvider(strings.NewReader(`{"access_token":"$aact_reader","link":"http://example.com"}`))
+	require.NoError(t, err)
+	credential, err := provider.Credential(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "$aact_reader", credential.AccessToken)
+	_, err = rest_asaas.NewReaderCredentialProvider(strings.NewReader(`{"link":"http://example.com"}`))
+	require.ErrorIs(t, err, model.ErrAccessTokenIsRequired)
+}
+
+func TestStaticCredentialProviderShouldValidateCredential(t *testing.T) {
+	_, err := rest_asaas.NewStaticCredentialProvider(model.NewCredential().SetAccessToken("$aact_static"))
+	require.ErrorIs(t, err, model.ErrLinkIsRequired)
+	_, err = rest_asaas.NewStaticCredentialProvider(nil)
+	require.ErrorIs(t, err, rest_asaas.ErrMissingAutenticationData)
+}
+
+func TestWatchedFileCredentialProviderShouldReloadRotatedKey(t *testing.T) {
+	path := writeCredential(t, "http://example.com")
+	provider, err := rest_asaas.NewWatchedFileCredentialProvider(path, 0)
+	require.NoError(t, err)
+	credential, err := provider.Credential(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "$aact_test", credential.AccessToken)
+	require.NoError(t, os.WriteFile(path, []byte(`{"access_token":"$aact_rotated","link":"http://example.com"}`), 0o600))
+	credential, err = provider.Credential(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "$aact_rotated", credential.AccessToken)
+	require.NoError(t, os.WriteFile(path, []byte(`{"link":"http://example.com"}`), 0o600))
+	credential, err = provider.Credential(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "$aact_rotated", credential.AccessToken, "last valid credential should be kept")
+	require.ErrorIs(t, provider.LastError(), model.ErrAccessTokenIsRequired)
+}
+
+func TestRestShouldUseRotatedCredential(t *testing.T) {
+	keys := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get("access_token")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"sub_1","customer":"cus_1","billingType":"BOLETO","nextDueDate":"2025-07-24","value":100,"cycle":"MONTHLY"}`))
+	}))
+	defer server.Close()
+	current := "$aact_first"
+	provider := rest_asaas.CredentialProviderFunc(func(ctx context.Context) (*model.Credential, error) {
+		return model.NewCredential().SetAccessToken(current).SetLink(server.URL), nil
+	})
+	restEntity, err := rest_asaas.NewRestWithProvider(rest_asaas.NewEngine(rest_asaas.Config{}), provider)
+	require.NoError(t, err)
+	_, err = restEntity.GetSubscription(context.Background(), "sub_1")
+	require.NoError(t, err)
+	require.Equal(t, "$aact_first", <-keys)
+	current = "$aact_second"
+	_, err = restEntity.GetSubscription(context.Background(), "sub_1")
+	require.NoError(t, err)
+	require.Equal(t, "$aact_second", <-keys)
+}
+
+func TestRestShouldReturnProviderError(t *testing.T) {
+	failure := errors.New("secrets manager unavailable")
+	provider := rest_asaas.CredentialProviderFunc(func(ctx context.Context) (*model.Credential, error) {
+		return nil, failure
+	})
+	restEntity, err := rest_asaas.NewRestWithProvider(rest_asaas.NewEngine(rest_asaas.Config{}), provider)
+	require.NoError(t, err)
+	_, err = restEntity.GetCustomer(context.Background(), "cus_1")
+	require.ErrorIs(t, err, failure)
+	_, err = rest_asaas.NewRestWithProvider(rest_asaas.NewEngine(rest_asaas.Config{}), nil)
+	require.ErrorIs(t, err, rest_asaas.ErrMissingCredentialProvider)
+}
@@ -0,0 +1,111 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestShouldUpdateOnlyDefinedFields(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	updated, err := restEntity.UpdateCustomer(context.Background(), customerID, model.NewCustomerUpdate().
+		SetEmail("john@example.com").
+		SetNotificationDisabled(true))
+	require.NoError(t, err, "Failed to update customer")
+	require.Equal(t, "john@example.com", updated.Email)
+	require.True(t, updated.NotificationDisabled)
+	require.Equal(t, "John Doe", updated.Name, "other fields should be kept")
+	requests := server.Requests()
+	require.JSONEq(t, `{"email":"john@example.com","notificationDisabled":true}`, requests[len(requests)-1].Body)
+}
+
+func TestRestShouldValidateCustomerUpdate(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	_, err := restEntity.UpdateCustomer(context.Background(), "", model.NewCustomerUpdate().SetName("John"))
+	require.ErrorIs(t, err, model.ErrCustomerIDIsRequired)
+	_, err = restEntity.UpdateCustomer(context.Background(), "cus_000000000001", model.NewCustomerUpdate())
+	require.ErrorIs(t, err, model.ErrNothingToUpdate)
+	require.Empty(t, server.Requests(), "invalid updates should not be sent")
+	_, err = restEntity.UpdateCustomer(context.Background(), "cus_000000000001", model.NewCustomerUpdate().SetName("John"))
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound)
+}
+
+func TestRestShouldDeleteAndRestoreCustomer(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	require.NoError(t, restEntity.DeleteCustomer(context.Background(), customerID), "Failed to delete customer")
+	stored, _ := server.Get(asaastest.RESOURCE_CUSTOMERS, customerID)
+	require.Equal(t, true, stored["deleted"])
+	_, err := restEntity.UpdateCustomer(context.Background(), customerID, model.NewCustomerUpdate().SetName("John"))
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerUpdateFailed, "deleted customers can't be changed")
+	restored, err := restEntity.RestoreCustomer(context.Background(), customerID)
+	require.NoError(t, err, "Failed to restore customer")
+	require.Equal(t, customerID, restored.ID)
+	require.False(t, restored.Deleted)
+	require.ErrorIs(t, restEntity.DeleteCustomer(context.Background(), "cus_000000000001"), rest_asaas.ErrCustomerNotFound)
+	_, err = restEntity.RestoreCustomer(context.Background(), "cus_000000000001")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound)
+	require.ErrorIs(t, restEntity.DeleteCustomer(context.Background(), ""), model.ErrCustomerIDIsRequired)
+}
+
+func TestRestShouldFilterCustomersByDocument(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	seedCustomer(server, "John Doe")
+	server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{"name": "ACME", "cpfCnpj": "11222333000181", "email": "acme@example.com"})
+	customers, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetCpfCnpj("11.222.333/0001-81"))
+	require.NoError(t, err)
+	require.Len(t, customers.Data, 1)
+	require.Equal(t, "ACME", customers.Data[0].Name)
+	requests := server.Requests()
+	require.Equal(t, "cpfCnpj=11222333000181", requests[len(requests)-1].Query)
+}
+
+func TestRestShouldNotListWithInvalidLimit(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	_, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetLimit(500))
+	require.ErrorIs(t, err, model.ErrInvalidLimit)
+	_, err = restEntity.ListAllCustomers(context.Background(), model.NewCustomerFilter().SetLimit(500), 0)
+	require.ErrorIs(t, err, model.ErrInvalidLimit)
+	require.Empty(t, server.Requests(), "invalid filters should not be sent")
+}
+
+func TestRestShouldEscapeIDsInThePath(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	err := restEntity.DeleteCustomer(context.Background(), customerID+"/restore")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound, "a slash should not reach another endpoint")
+	stored, _ := server.Get(asaastest.RESOURCE_CUSTOMERS, customerID)
+	require.NotEqual(t, true, stored["deleted"])
+	_, err = restEntity.GetPayment(context.Background(), "pay_000000000001?limit=1")
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentNotFound)
+	requests := server.Requests()
+	require.Equal(t, "/v3/customers/"+customerID+"%2Frestore", requests[0].Path)
+	require.Equal(t, "/v3/payments/pay_000000000001%3Flimit=1", requests[1].Path)
+	require.Empty(t, requests[1].Query, "a question mark should not start a query")
+}
+
+func TestRestShouldReadCustomerWithoutContactInfo(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{"name": "John Doe", "cpfCnpj": "00000000191"})
+	customer, err := restEntity.GetCustomer(context.Background(), customerID)
+	require.NoError(t, err, "Asaas does not require contact info")
+	require.Equal(t, customerID, customer.ID)
+	require.NoError(t, restEntity.DeleteCustomer(context.Background(), customerID))
+	_, err = restEntity.RestoreCustomer(context.Background(), customerID)
+	require.NoError(t, err)
+}
+
+func TestRestShouldUpdateCustomerDownToNoContactInfo(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{"name": "John Doe", "cpfCnpj": "00000000191", "email": "john@example.com"})
+	updated, err := restEntity.UpdateCustomer(context.Background(), customerID, model.NewCustomerUpdate().SetEmail(""))
+	require.NoError(t, err, "a change applied by Asaas should not be reported as failed")
+	require.Empty(t, updated.Email)
+	stored, _ := server.Get(asaastest.RESOURCE_CUSTOMERS, customerID)
+	require.Equal(t, "", stored["email"])
+}
@@ -0,0 +1,97 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineShouldAbortRequestWhenContextIsCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := engine.GetWithHeaderNoAuth(ctx, nil, server.URL, map[string]string{})
+	require.Error(t, err, "request should fail when context deadline is exceeded")
+	require.ErrorIs(t, err, context.DeadlineExceeded, "error should be context.DeadlineExceeded")
+}
+
+func TestEngineShouldSendRequestWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "John Doe", r.URL.Query().Get("name"), "query should be sent")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	result, err := engine.GetWithHeaderNoAuth(context.Background(), map[string]interface{}{"name": "John Doe"}, server.URL, map[string]string{})
+	require.NoError(t, err, "request should succeed")
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.Equal(t, `{"ok":true}`, result.GetRaw())
+}
+
+func TestRestShouldNotCallEngineWhenContextIsCancelled(t *testing.T) {
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	restEntity, err := rest_asaas.NewRest(engine, writeCredential(t, "http://127.0.0.1:1"))
+	require.NoError(t, err, "Failed to create rest entity")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = restEntity.GetCustomer(ctx, "cus_000006724433")
+	require.ErrorIs(t, err, context.Canceled, "error should be context.Canceled")
+}
+
+func TestEngineShouldSendEveryVerb(t *testing.T) {
+	methods := make(chan string, 5)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods <- r.Method
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	payload := map[string]interface{}{"name": "John Doe"}
+	header := map[string]string{}
+	_, err := engine.GetWithHeaderNoAuth(context.Background(), nil, server.URL, header)
+	require.NoError(t, err)
+	_, err = engine.PostWithHeaderNoAuth(context.Background(), payload, server.URL, header)
+	require.NoError(t, err)
+	_, err = engine.PutWithHeaderNoAuth(context.Background(), payload, server.URL, header)
+	require.NoError(t, err)
+	_, err = engine.PatchWithHeaderNoAuth(context.Background(), payload, server.URL, header)
+	require.NoError(t, err)
+	_, err = engine.DeleteWithHeaderNoAuth(context.Background(), server.URL, header)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodGet, <-methods)
+	require.Equal(t, http.MethodPost, <-methods)
+	require.Equal(t, http.MethodPut, <-methods)
+	require.Equal(t, http.MethodPatch, <-methods)
+	require.Equal(t, http.MethodDelete, <-methods)
+}
+
+func TestRestShouldAcceptAnySuccessStatus(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusCreated, `{"object":"customer","id":"cus_1","name":"John Doe","cpfCnpj":"00000000191","mobilePhone":"31999999999"}`)
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+	created, err := restEntity.CreateCustomer(context.Background(), customer)
+	require.NoError(t, err)
+	require.Equal(t, "cus_1", created.ID)
+}
+
+func TestRestShouldDecodeDeleteResponse(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusOK, `{"deleted":true,"id":"sub_1"}`)
+	require.NoError(t, restEntity.Unsubscribe(context.Background(), "sub_1"))
+	require.ErrorIs(t, restEntity.Unsubscribe(context.Background(), ""), rest_asaas.ErrSubscriptionIDIsRequired)
+}
@@ -0,0 +1,83 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+// creates a rest entity pointing to a server that always answers with the given status and body
+func newRestWithResponse(t *testing.T, status int, body string) *rest_asaas.Rest {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	engine.SetRetryPolicy(rest_asaas.NoRetryPolicy())
+	restEntity, err := rest_asaas.NewRest(engine, writeCredential(t, server.URL))
+	require.NoError(t, err, "Failed to create rest entity")
+	return restEntity
+}
+
+func TestAPIErrorShouldExposeAsaasErrorCodes(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusBadRequest, `{"errors":[{"code":"invalid_cpfCnpj","description":"O CPF/CNPJ informado é inválido."}]}`)
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+	_, err := restEntity.CreateCustomer(context.Background(), customer)
+	var apiError *rest_asaas.APIError
+	require.True(t, errors.As(err, &apiError), "error should be an APIError")
+	require.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+	require.Equal(t, "/v3/customers", apiError.Endpoint)
+	require.True(t, apiError.HasCode("invalid_cpfCnpj"), "error should carry the Asaas code")
+	require.False(t, apiError.HasCode("invalid_customer"), "error should not carry other codes")
+	require.Equal(t, []string{"invalid_cpfCnpj"}, apiError.Codes())
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerCreationFailed)
+}
+
+func TestAPIErrorShouldMatchNotFoundSentinel(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusNotFound, ``)
+	_, err := restEntity.GetCustomer(context.Background(), "cus_000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound)
+	_, err = restEntity.GetSubscription(context.Background(), "sub_000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrSubscriptionNotFound)
+}
+
+func TestAPIErrorShouldKeepSentinelWhenBodyIsNotJSON(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusInternalServerError, `<html>bad gateway</html>`)
+	_, err := restEntity.GetSubscription(context.Background(), "sub_000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrSubscriptionFailed)
+	require.NotErrorIs(t, err, rest_asaas.ErrCustomerCreationFailed)
+	var apiError *rest_asaas.APIError
+	require.True(t, errors.As(err, &apiError), "error should be an APIError")
+	require.Equal(t, http.StatusInternalServerError, apiError.StatusCode)
+	require.Equal(t, `<html>bad gateway</html>`, apiError.Raw)
+	require.Empty(t, apiError.Errors)
+}
+
+func TestAPIErrorShouldBeReturnedOnUnsubscribe(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusNotFound, `{"errors":[{"code":"invalid_action","description":"Assinatura inexistente."}]}`)
+	err := restEntity.Unsubscribe(context.Background(), "sub_000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrSubscriptionNotFound)
+	var apiError *rest_asaas.APIError
+	require.True(t, errors.As(err, &apiError), "error should be an APIError")
+	require.True(t, apiError.HasCode("invalid_action"))
+}
+
+func TestRestShouldReportMalformedSuccessBody(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusOK, `{"id":`)
+	_, err := restEntity.GetCustomer(context.Background(), "cus_000000000001")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerRetrievalFailed)
+	require.ErrorIs(t, err, rest_asaas.ErrUnexpectedResponse)
+	var apiErr *rest_asaas.APIError
+	require.False(t, errors.As(err, &apiErr), "2xx answers are not API errors")
+}
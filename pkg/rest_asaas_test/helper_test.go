@@ -0,0 +1,39 @@
+package rest_asaas_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+// writes a credential file pointing to the given link and returns its path
+func writeCredential(t *testing.T, link string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credential.json")
+	data := []byte(`{"access_token":"$aact_test","link":"` + link + `"}`)
+	require.NoError(t, os.WriteFile(path, data, 0o600), "should write credential file")
+	return path
+}
+
+// starts a fake Asaas server and a client pointing to it
+func newFakeServer(t *testing.T, opts ...rest_asaas.Option) (*asaastest.Server, *rest_asaas.Rest) {
+	t.Helper()
+	server := asaastest.NewServer()
+	t.Cleanup(server.Close)
+	restEntity, err := server.Client(opts...)
+	require.NoError(t, err, "Failed to create rest entity")
+	return server, restEntity
+}
+
+// stores a customer in the fake server and returns its ID
+func seedCustomer(server *asaastest.Server, name string) string {
+	return server.Seed(asaastest.RESOURCE_CUSTOMERS, map[string]interface{}{
+		"name":        name,
+		"cpfCnpj":     "00000000191",
+		"mobilePhone": "31999999999",
+	})
+}
@@ -0,0 +1,80 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/pericles-luz/go-asaas/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestShouldTraceOperations(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"invalid_cpfCnpj","description":"invalid"}]}`))
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"cus_1","name":"John Doe","cpfCnpj":"00000000191","email":"john@doe.com"}`))
+	}))
+	defer server.Close()
+	memory := telemetry.NewMemory()
+	policy := rest_asaas.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	restEntity, err := rest_asaas.NewClient(
+		rest_asaas.WithCredentialFile(writeCredential(t, server.URL)),
+		rest_asaas.WithRetryPolicy(policy),
+		rest_asaas.WithTracer(memory),
+		rest_asaas.WithMeter(memory),
+	)
+	require.NoError(t, err)
+	_, err = restEntity.GetCustomer(context.Background(), "cus_1")
+	require.NoError(t, err)
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+	_, err = restEntity.CreateCustomer(context.Background(), customer)
+	require.Error(t, err)
+
+	spans := memory.Spans()
+	require.Len(t, spans, 2)
+	require.Equal(t, "asaas.GetCustomer", spans[0].Name)
+	require.Equal(t, http.StatusOK, spans[0].Attributes[telemetry.ATTRIBUTE_STATUS_CODE])
+	require.Equal(t, 1, spans[0].Attributes[telemetry.ATTRIBUTE_RETRIES])
+	require.Empty(t, spans[0].Errors)
+	require.Equal(t, "asaas.CreateCustomer", spans[1].Name)
+	require.Equal(t, http.StatusBadRequest, spans[1].Attributes[telemetry.ATTRIBUTE_STATUS_CODE])
+	require.Equal(t, []string{"invalid_cpfCnpj"}, spans[1].Attributes[telemetry.ATTRIBUTE_ERROR_CODES])
+	require.Len(t, spans[1].Errors, 1)
+
+	measurements := memory.Measurements()
+	require.Len(t, measurements, 2)
+	require.Equal(t, telemetry.METRIC_DURATION, measurements[0].Name)
+	require.Equal(t, "GetCustomer", measurements[0].Attributes[telemetry.ATTRIBUTE_OPERATION])
+	histogram := memory.Histogram(telemetry.METRIC_DURATION, telemetry.DefaultLatencyBounds, map[string]any{telemetry.ATTRIBUTE_OPERATION: "CreateCustomer"})
+	total := 0
+	for _, count := range histogram {
+		total += count
+	}
+	require.Equal(t, 1, total)
+}
+
+func TestRestShouldNotRequireInstrumentation(t *testing.T) {
+	restEntity := newRestWithResponse(t, http.StatusOK, `{"deleted":true,"id":"sub_1"}`)
+	restEntity.SetTracer(nil)
+	restEntity.SetMeter(nil)
+	require.NoError(t, restEntity.Unsubscribe(context.Background(), "sub_1"))
+}
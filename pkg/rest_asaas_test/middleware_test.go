@@ -0,0 +1,95 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewaresShouldRunInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "first,second", r.Header.Get("X-Trace"), "headers set by middlewares should be sent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	calls := []string{}
+	trace := func(name string) rest_asaas.Middleware {
+		return func(next rest_asaas.Handler) rest_asaas.Handler {
+			return func(ctx context.Context, request *rest_asaas.HTTPRequest) (*rest_asaas.HTTPResponse, error) {
+				calls = append(calls, "before "+name)
+				if request.Header["X-Trace"] != "" {
+					request.Header["X-Trace"] += ","
+				}
+				request.Header["X-Trace"] += name
+				response, err := next(ctx, request)
+				calls = append(calls, "after "+name)
+				return response, err
+			}
+		}
+	}
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	engine.Use(trace("first"))
+	engine.Use(trace("second"))
+	header := map[string]string{}
+	_, err := engine.GetWithHeaderNoAuth(context.Background(), nil, server.URL, header)
+	require.NoError(t, err)
+	require.Equal(t, []string{"before first", "before second", "after second", "after first"}, calls)
+	require.Empty(t, header, "caller headers should not be changed")
+}
+
+func TestAfterResponseShouldSeeRequestAndResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"cus_1"}`))
+	}))
+	defer server.Close()
+	var seenRequest *rest_asaas.HTTPRequest
+	var seenResponse *rest_asaas.HTTPResponse
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	engine.Use(rest_asaas.AfterResponse(func(ctx context.Context, request *rest_asaas.HTTPRequest, response *rest_asaas.HTTPResponse, err error) {
+		seenRequest = request
+		seenResponse = response
+	}))
+	_, err := engine.PostWithHeaderNoAuth(context.Background(), map[string]interface{}{"name": "John Doe"}, server.URL+"/v3/customers", map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, seenRequest.Method)
+	require.Equal(t, server.URL+"/v3/customers", seenRequest.URL)
+	require.Equal(t, "John Doe", seenRequest.Body["name"])
+	require.Equal(t, http.StatusCreated, seenResponse.StatusCode)
+	require.Equal(t, `{"id":"cus_1"}`, seenResponse.Body)
+	require.Positive(t, seenResponse.Duration)
+}
+
+func TestBeforeRequestShouldAbortOnError(t *testing.T) {
+	failure := errors.New("blocked by audit")
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	engine.Use(rest_asaas.BeforeRequest(func(ctx context.Context, request *rest_asaas.HTTPRequest) error {
+		return failure
+	}))
+	_, err := engine.DeleteWithHeaderNoAuth(context.Background(), "http://127.0.0.1:1/v3/customers/cus_1", map[string]string{})
+	require.ErrorIs(t, err, failure)
+}
+
+func TestMiddlewareShouldWorkAsFake(t *testing.T) {
+	fake := func(next rest_asaas.Handler) rest_asaas.Handler {
+		return func(ctx context.Context, request *rest_asaas.HTTPRequest) (*rest_asaas.HTTPResponse, error) {
+			return &rest_asaas.HTTPResponse{
+				StatusCode: http.StatusOK,
+				Body:       `{"id":"sub_1","customer":"cus_1","billingType":"BOLETO","nextDueDate":"2025-07-24","value":100,"cycle":"MONTHLY"}`,
+			}, nil
+		}
+	}
+	restEntity, err := rest_asaas.NewClient(
+		rest_asaas.WithCredentialFile(writeCredential(t, "http://127.0.0.1:1")),
+		rest_asaas.WithMiddleware(fake),
+	)
+	require.NoError(t, err)
+	subscription, err := restEntity.GetSubscription(context.Background(), "sub_1")
+	require.NoError(t, err)
+	require.Equal(t, "sub_1", subscription.ID)
+}
@@ -0,0 +1,115 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestShouldListCustomerNotifications(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	seedCustomer(server, "Jane Doe")
+	notifications, err := restEntity.ListCustomerNotifications(context.Background(), customerID)
+	require.NoError(t, err, "Failed to list notifications")
+	require.Len(t, notifications.Data, 8, "overdue notices and due date warnings have two notifications each")
+	for _, notification := range notifications.Data {
+		require.Equal(t, customerID, notification.CustomerID)
+		require.True(t, notification.Event.Valid())
+	}
+	_, err = restEntity.ListCustomerNotifications(context.Background(), "cus_000000000001")
+	require.ErrorIs(t, err, rest_asaas.ErrCustomerNotFound)
+}
+
+func TestRestShouldUpdateNotification(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	notifications, err := restEntity.ListCustomerNotifications(context.Background(), seedCustomer(server, "John Doe"))
+	require.NoError(t, err)
+	notification := notifications.Data[0]
+	updated, err := restEntity.UpdateNotification(context.Background(), notification.SetChannel(model.CHANNEL_WHATSAPP, true))
+	require.NoError(t, err, "Failed to update notification")
+	require.True(t, updated.WhatsappEnabledForCustomer)
+	_, err = restEntity.UpdateNotification(context.Background(), model.NewNotification().SetID("not_1"))
+	require.ErrorIs(t, err, rest_asaas.ErrNotificationNotFound)
+}
+
+func TestRestShouldApplyNotificationProfileToNewCustomer(t *testing.T) {
+	_, restEntity := newFakeServer(t)
+	customer, err := restEntity.CreateCustomer(context.Background(), model.NewCustomer().
+		SetName("ACME").
+		SetCpfCnpj("11222333000181").
+		SetEmail("acme@example.com"))
+	require.NoError(t, err)
+	profile := model.NewNotificationProfile(
+		model.NotificationTemplate{
+			Event:            model.NOTIFICATION_PAYMENT_DUEDATE_WARNING,
+			Enabled:          true,
+			Channels:         []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_WHATSAPP},
+			ProviderChannels: []model.NotificationChannel{model.CHANNEL_EMAIL},
+			ScheduleOffset:   10,
+		},
+		model.NotificationTemplate{Event: model.NOTIFICATION_PAYMENT_OVERDUE, Enabled: true, Channels: []model.NotificationChannel{model.CHANNEL_VOICE}, ScheduleOffset: 7},
+	)
+	changed, err := restEntity.ApplyNotificationProfile(context.Background(), customer.ID, profile)
+	require.NoError(t, err, "Failed to apply profile")
+	require.Len(t, changed, 2)
+	notifications, err := restEntity.ListCustomerNotifications(context.Background(), customer.ID)
+	require.NoError(t, err)
+	offsets := map[model.NotificationEvent][]int{}
+	for _, notification := range notifications.Data {
+		offsets[notification.Event] = append(offsets[notification.Event], notification.ScheduleOffset)
+		switch {
+		case notification.Event == model.NOTIFICATION_PAYMENT_DUEDATE_WARNING && notification.ScheduleOffset == 10:
+			require.Equal(t, []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_WHATSAPP}, notification.Channels())
+			require.True(t, notification.EmailEnabledForProvider)
+		case notification.Event == model.NOTIFICATION_PAYMENT_OVERDUE && notification.ScheduleOffset == 7:
+			require.Equal(t, []model.NotificationChannel{model.CHANNEL_VOICE}, notification.Channels())
+		default:
+			require.Equal(t, []model.NotificationChannel{model.CHANNEL_EMAIL, model.CHANNEL_SMS}, notification.Channels(), "other notifications should keep the defaults")
+		}
+	}
+	require.ElementsMatch(t, []int{10, 0}, offsets[model.NOTIFICATION_PAYMENT_DUEDATE_WARNING], "both warnings should keep their offsets")
+	require.ElementsMatch(t, []int{0, 7}, offsets[model.NOTIFICATION_PAYMENT_OVERDUE], "both overdue notices should keep their offsets")
+}
+
+func TestRestShouldApplyNotificationProfileToEveryPage(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	for _, offset := range []int{1, 5, 15, 30} {
+		server.Seed(asaastest.RESOURCE_NOTIFICATIONS, map[string]interface{}{
+			"customer":       customerID,
+			"event":          string(model.NOTIFICATION_PAYMENT_DUEDATE_WARNING),
+			"enabled":        true,
+			"scheduleOffset": offset,
+		})
+	}
+	first, err := restEntity.ListCustomerNotifications(context.Background(), customerID)
+	require.NoError(t, err)
+	require.True(t, first.HasMore, "the notifications should not fit in a single page")
+	profile := model.NewNotificationProfile(model.NotificationTemplate{
+		Event:          model.NOTIFICATION_PAYMENT_DUEDATE_WARNING,
+		Enabled:        true,
+		Channels:       []model.NotificationChannel{model.CHANNEL_WHATSAPP},
+		ScheduleOffset: 30,
+	})
+	changed, err := restEntity.ApplyNotificationProfile(context.Background(), customerID, profile)
+	require.NoError(t, err)
+	require.Len(t, changed, 1, "the notification on the second page should be found")
+	require.Equal(t, []model.NotificationChannel{model.CHANNEL_WHATSAPP}, changed[0].Channels())
+	notifications, err := rest_asaas.ListAll(restEntity.CustomerNotifications(context.Background(), customerID), 0)
+	require.NoError(t, err)
+	require.Len(t, notifications, 12)
+}
+
+func TestRestShouldValidateNotificationBatch(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	_, err := restEntity.UpdateNotifications(context.Background(), model.NewNotificationBatch("cus_1"))
+	require.ErrorIs(t, err, model.ErrNoNotifications)
+	_, err = restEntity.ApplyNotificationProfile(context.Background(), "cus_1", model.NewNotificationProfile(model.NotificationTemplate{Event: "UNKNOWN"}))
+	require.ErrorIs(t, err, model.ErrInvalidNotificationEvent)
+	require.Empty(t, server.Requests())
+}
@@ -0,0 +1,102 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+// creates a client whose server lists total customers in pages of limit items
+func newPaginatedRest(t *testing.T, total int, limit int, requests *atomic.Int32) *rest_asaas.Rest {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := model.NewCustomerList()
+		page.Offset = offset
+		page.Limit = limit
+		page.TotalCount = total
+		for i := offset; i < total && i < offset+limit; i++ {
+			page.Data = append(page.Data, model.Customer{ID: fmt.Sprintf("cus_%d", i), Name: "John Doe"})
+		}
+		page.HasMore = offset+limit < total
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	restEntity, err := rest_asaas.NewRest(rest_asaas.NewEngine(rest_asaas.Config{}), writeCredential(t, server.URL))
+	require.NoError(t, err, "Failed to create rest entity")
+	return restEntity
+}
+
+func TestCustomersShouldIterateOverEveryPage(t *testing.T) {
+	requests := &atomic.Int32{}
+	restEntity := newPaginatedRest(t, 7, 3, requests)
+	ids := []string{}
+	for customer, err := range restEntity.Customers(context.Background(), model.NewCustomerFilter().SetName("John Doe")) {
+		require.NoError(t, err)
+		ids = append(ids, customer.ID)
+	}
+	require.Equal(t, []string{"cus_0", "cus_1", "cus_2", "cus_3", "cus_4", "cus_5", "cus_6"}, ids)
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestCustomersShouldFetchPagesLazily(t *testing.T) {
+	requests := &atomic.Int32{}
+	restEntity := newPaginatedRest(t, 30, 10, requests)
+	count := 0
+	for _, err := range restEntity.Customers(context.Background(), nil) {
+		require.NoError(t, err)
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	require.Equal(t, int32(1), requests.Load(), "only the first page should be fetched")
+}
+
+func TestCustomersShouldStopWhenContextIsCancelled(t *testing.T) {
+	requests := &atomic.Int32{}
+	restEntity := newPaginatedRest(t, 30, 10, requests)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lastErr error
+	for _, err := range restEntity.Customers(ctx, nil) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		cancel()
+	}
+	require.ErrorIs(t, lastErr, context.Canceled)
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestListAllCustomersShouldCollectEveryItem(t *testing.T) {
+	requests := &atomic.Int32{}
+	restEntity := newPaginatedRest(t, 25, 10, requests)
+	customers, err := restEntity.ListAllCustomers(context.Background(), nil, 0)
+	require.NoError(t, err)
+	require.Len(t, customers, 25)
+}
+
+func TestListAllCustomersShouldGuardMaxItems(t *testing.T) {
+	requests := &atomic.Int32{}
+	restEntity := newPaginatedRest(t, 25, 10, requests)
+	customers, err := restEntity.ListAllCustomers(context.Background(), nil, 12)
+	require.ErrorIs(t, err, rest_asaas.ErrTooManyItems)
+	require.Len(t, customers, 12)
+	require.Equal(t, int32(2), requests.Load())
+	customers, err = restEntity.ListAllCustomers(context.Background(), nil, 25)
+	require.NoError(t, err)
+	require.Len(t, customers, 25)
+}
@@ -0,0 +1,191 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
+	"github.com/pericles-luz/go-asaas/pkg/boleto"
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/pix"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func seedPayment(server *asaastest.Server, customerID string, dueDate string, status model.PaymentStatus) string {
+	return server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{
+		"customer":    customerID,
+		"billingType": "BOLETO",
+		"value":       50,
+		"dueDate":     dueDate,
+		"status":      string(status),
+	})
+}
+
+func TestRestShouldCreateAndGetPayment(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	payment, err := restEntity.CreatePayment(context.Background(), model.NewPayment().
+		SetCustomerID(customerID).
+		SetBillingType(model.BILLING_TYPE_PIX).
+		SetValue(model.Cents(1990)).
+		SetDueDate(model.Today().AddDays(3)).
+		SetDescription("Pedido 1"))
+	require.NoError(t, err, "Failed to create payment")
+	require.Regexp(t, `^pay_[a-z0-9]{16}$`, payment.ID)
+	require.Equal(t, model.PAYMENT_STATUS_PENDING, payment.Status)
+	require.Equal(t, model.Cents(1990), payment.Value)
+	requests := server.Requests()
+	require.JSONEq(t, `{"customer":"`+customerID+`","billingType":"PIX","value":19.90,"dueDate":"`+model.Today().AddDays(3).String()+`","description":"Pedido 1","postalService":false}`, requests[len(requests)-1].Body)
+	stored, err := restEntity.GetPayment(context.Background(), payment.ID)
+	require.NoError(t, err, "Failed to get payment")
+	require.Equal(t, payment.ID, stored.ID)
+	require.Equal(t, "Pedido 1", stored.Description)
+	_, err = restEntity.GetPayment(context.Background(), "pay_0000000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentNotFound)
+}
+
+func TestRestShouldNotCreateInvalidPayment(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	_, err := restEntity.CreatePayment(context.Background(), model.NewPayment().SetCustomerID("cus_000000000001"))
+	require.ErrorIs(t, err, model.ErrBillingTypeIsRequired)
+	require.Empty(t, server.Requests(), "invalid payments should not be sent")
+	_, err = restEntity.CreatePayment(context.Background(), model.NewPayment().
+		SetCustomerID("cus_000000000001").
+		SetBillingType(model.BILLING_TYPE_BOLETO).
+		SetValue(model.Reais(10)).
+		SetDueDate(model.Today()))
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentCreationFailed, "unknown customers should be refused by Asaas")
+}
+
+func TestRestShouldUpdateDeleteAndRestorePayment(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	paymentID := seedPayment(server, seedCustomer(server, "John Doe"), "2026-05-10", model.PAYMENT_STATUS_PENDING)
+	dueDate := model.NewDate(2026, time.May, 20)
+	updated, err := restEntity.UpdatePayment(context.Background(), paymentID, model.NewPaymentUpdate().
+		SetDueDate(dueDate).
+		SetValue(model.Cents(4590)))
+	require.NoError(t, err, "Failed to update payment")
+	require.Equal(t, dueDate, updated.DueDate)
+	require.Equal(t, model.Cents(4590), updated.Value)
+	require.Equal(t, model.BILLING_TYPE_BOLETO, updated.BillingType, "other fields should be kept")
+	require.NoError(t, restEntity.DeletePayment(context.Background(), paymentID), "Failed to delete payment")
+	_, err = restEntity.UpdatePayment(context.Background(), paymentID, model.NewPaymentUpdate().SetDescription("x"))
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentUpdateFailed, "deleted payments can't be changed")
+	restored, err := restEntity.RestorePayment(context.Background(), paymentID)
+	require.NoError(t, err, "Failed to restore payment")
+	require.False(t, restored.Deleted)
+	require.ErrorIs(t, restEntity.DeletePayment(context.Background(), ""), model.ErrPaymentIDIsRequired)
+	require.ErrorIs(t, restEntity.DeletePayment(context.Background(), "pay_0000000000000000"), rest_asaas.ErrPaymentNotFound)
+	_, err = restEntity.UpdatePayment(context.Background(), paymentID, model.NewPaymentUpdate())
+	require.ErrorIs(t, err, model.ErrNothingToUpdate)
+}
+
+func TestRestShouldFilterPayments(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	otherID := seedCustomer(server, "Jane Doe")
+	seedPayment(server, customerID, "2026-04-30", model.PAYMENT_STATUS_RECEIVED)
+	may := seedPayment(server, customerID, "2026-05-15", model.PAYMENT_STATUS_OVERDUE)
+	seedPayment(server, customerID, "2026-06-01", model.PAYMENT_STATUS_OVERDUE)
+	seedPayment(server, otherID, "2026-05-15", model.PAYMENT_STATUS_OVERDUE)
+	filter := model.NewPaymentFilter().
+		SetCustomerID(customerID).
+		SetStatus(model.PAYMENT_STATUS_OVERDUE).
+		SetDueDate(model.NewDate(2026, time.May, 1), model.NewDate(2026, time.May, 31))
+	payments, err := restEntity.ListPayments(context.Background(), filter)
+	require.NoError(t, err, "Failed to list payments")
+	require.Len(t, payments.Data, 1)
+	require.Equal(t, may, payments.Data[0].ID)
+	requests := server.Requests()
+	query, err := url.ParseQuery(requests[len(requests)-1].Query)
+	require.NoError(t, err)
+	require.Equal(t, "2026-05-01", query.Get("dueDate[ge]"))
+	require.Equal(t, "2026-05-31", query.Get("dueDate[le]"))
+	all, err := restEntity.ListAllPayments(context.Background(), model.NewPaymentFilter().SetCustomerID(customerID).SetLimit(2), 0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	_, err = restEntity.ListPayments(context.Background(), model.NewPaymentFilter().SetStatus("PAID"))
+	require.ErrorIs(t, err, model.ErrInvalidPaymentStatus)
+}
+
+func TestRestShouldGetPaymentIdentificationField(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	paymentID := seedPayment(server, customerID, "2026-05-15", model.PAYMENT_STATUS_PENDING)
+	field, err := restEntity.GetPaymentIdentificationField(context.Background(), paymentID)
+	require.NoError(t, err, "Failed to get identification field")
+	require.NoError(t, boleto.ValidateMatch(field.IdentificationField, field.BarCode))
+	require.Equal(t, model.Reais(50), field.Value())
+	parsed, err := boleto.Parse(field.BarCode)
+	require.NoError(t, err)
+	require.Equal(t, asaastest.BANK_CODE, parsed.Bank)
+	dueDate := boleto.DueDate(parsed.DueFactor, time.Now())
+	require.Equal(t, model.NewDate(2026, time.May, 15), model.NewDate(dueDate.Year(), dueDate.Month(), dueDate.Day()))
+	stored, _ := server.Get(asaastest.RESOURCE_PAYMENTS, paymentID)
+	require.Equal(t, field.NossoNumero, stored["nossoNumero"])
+}
+
+func TestRestShouldRefuseCorruptedIdentificationField(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	paymentID := seedPayment(server, seedCustomer(server, "John Doe"), "2026-05-15", model.PAYMENT_STATUS_PENDING)
+	server.InjectFault(asaastest.Fault{
+		PathPrefix: "/v3/payments/" + paymentID + "/identificationField",
+		Status:     http.StatusOK,
+		Body:       `{"identificationField":"00190500954014481606906809350314337370000000200","nossoNumero":"6453","barCode":"00193373700000001000500940144816060680935031"}`,
+		Times:      1,
+	})
+	_, err := restEntity.GetPaymentIdentificationField(context.Background(), paymentID)
+	require.ErrorIs(t, err, boleto.ErrInvalidLine)
+	pix := server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{"customer": "cus_1", "billingType": "PIX", "value": 10, "dueDate": "2026-05-15"})
+	_, err = restEntity.GetPaymentIdentificationField(context.Background(), pix)
+	require.ErrorIs(t, err, rest_asaas.ErrIdentificationFieldFailed, "only boletos have a digitable line")
+	_, err = restEntity.GetPaymentIdentificationField(context.Background(), "pay_0000000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentNotFound)
+}
+
+func TestRestShouldGetPaymentPixQrCode(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	paymentID := server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{
+		"customer":    seedCustomer(server, "John Doe"),
+		"billingType": "PIX",
+		"value":       19.9,
+		"dueDate":     "2026-05-15",
+	})
+	qrCode, err := restEntity.GetPaymentPixQrCode(context.Background(), paymentID)
+	require.NoError(t, err, "Failed to get pix qr code")
+	require.NotNil(t, qrCode.Image)
+	require.NotEmpty(t, qrCode.PNG)
+	require.NoError(t, pix.ValidateCRC(qrCode.Payload))
+	require.Equal(t, model.Cents(1990), qrCode.Value())
+	require.Equal(t, model.NewDate(2026, time.May, 15), qrCode.ExpirationDate.Date())
+	_, err = restEntity.GetPaymentPixQrCode(context.Background(), "pay_0000000000000000")
+	require.ErrorIs(t, err, rest_asaas.ErrPaymentNotFound)
+	_, err = restEntity.GetPaymentPixQrCode(context.Background(), "")
+	require.ErrorIs(t, err, model.ErrPaymentIDIsRequired)
+}
+
+func TestRestShouldRefuseCorruptedPixQrCode(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	paymentID := server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{"customer": "cus_1", "billingType": "PIX", "value": 10, "dueDate": "2026-05-15"})
+	qrCode, err := restEntity.GetPaymentPixQrCode(context.Background(), paymentID)
+	require.NoError(t, err)
+	corrupted := qrCode.Payload[:len(qrCode.Payload)-1] + "0"
+	if corrupted == qrCode.Payload {
+		corrupted = qrCode.Payload[:len(qrCode.Payload)-1] + "1"
+	}
+	server.InjectFault(asaastest.Fault{
+		PathPrefix: "/v3/payments/" + paymentID + "/pixQrCode",
+		Status:     http.StatusOK,
+		Body:       `{"encodedImage":"` + qrCode.EncodedImage + `","payload":"` + corrupted + `","expirationDate":"2026-05-15 23:59:59"}`,
+		Times:      1,
+	})
+	_, err = restEntity.GetPaymentPixQrCode(context.Background(), paymentID)
+	require.ErrorIs(t, err, pix.ErrInvalidCRC)
+	card := server.Seed(asaastest.RESOURCE_PAYMENTS, map[string]interface{}{"customer": "cus_1", "billingType": "CREDIT_CARD", "value": 10, "dueDate": "2026-05-15"})
+	_, err = restEntity.GetPaymentPixQrCode(context.Background(), card)
+	require.ErrorIs(t, err, rest_asaas.ErrPixQrCodeFailed)
+}
@@ -0,0 +1,101 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterShouldRejectInvalidLimits(t *testing.T) {
+	_, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 0, Burst: 1})
+	require.ErrorIs(t, err, rest_asaas.ErrInvalidRateLimit)
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.ErrorIs(t, limiter.SetFamilyLimit("customers", rest_asaas.RateLimit{Rate: 1}), rest_asaas.ErrInvalidRateLimit)
+}
+
+func TestRateLimiterShouldBlockUntilBudgetIsAvailable(t *testing.T) {
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 20, Burst: 2})
+	require.NoError(t, err)
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, limiter.Wait(context.Background(), "key", "customers"))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond, "third and fourth calls should wait for refill")
+}
+
+func TestRateLimiterShouldStopWaitingWhenContextExpires(t *testing.T) {
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 0.1, Burst: 1})
+	require.NoError(t, err)
+	require.NoError(t, limiter.Wait(context.Background(), "key", "customers"))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, limiter.Wait(ctx, "key", "customers"), context.DeadlineExceeded)
+}
+
+func TestRateLimiterShouldKeepSeparateBudgets(t *testing.T) {
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 100, Burst: 100})
+	require.NoError(t, err)
+	require.NoError(t, limiter.SetFamilyLimit("customers", rest_asaas.RateLimit{Rate: 0.1, Burst: 1}))
+	require.NoError(t, limiter.Wait(context.Background(), "key", "customers"))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, limiter.Wait(ctx, "key", "customers"), context.DeadlineExceeded, "customers budget should be exhausted")
+	require.NoError(t, limiter.Wait(context.Background(), "key", "subscriptions"), "other families should not be affected")
+	require.NoError(t, limiter.Wait(context.Background(), "other-key", "customers"), "other keys should not be affected")
+}
+
+func TestRateLimiterShouldBeSharedByEngines(t *testing.T) {
+	var mutex sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		calls++
+		mutex.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 0.1, Burst: 1})
+	require.NoError(t, err)
+	first := rest_asaas.NewEngine(rest_asaas.Config{})
+	first.SetRateLimiter(limiter)
+	second := rest_asaas.NewEngine(rest_asaas.Config{})
+	second.SetRateLimiter(limiter)
+	header := map[string]string{"access_token": "$aact_test"}
+	_, err = first.GetWithHeaderNoAuth(context.Background(), nil, server.URL+"/v3/customers", header)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = second.GetWithHeaderNoAuth(ctx, nil, server.URL+"/v3/customers", header)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "second engine should share the exhausted budget")
+	require.Equal(t, 1, calls)
+}
+
+func TestRateLimiterShouldDropIdleBuckets(t *testing.T) {
+	limiter, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 1_000_000, Burst: 1})
+	require.NoError(t, err)
+	require.NoError(t, limiter.SetDefaultFamilyLimit(rest_asaas.RateLimit{Rate: 1_000_000, Burst: 1}))
+	for i := range 1000 {
+		require.NoError(t, limiter.Wait(context.Background(), fmt.Sprintf("$aact_rotated_%d", i), "customers"))
+	}
+	require.Less(t, limiter.Size(), 200, "buckets of rotated keys should not pile up")
+	slow, err := rest_asaas.NewRateLimiter(rest_asaas.RateLimit{Rate: 0.001, Burst: 1})
+	require.NoError(t, err)
+	for i := range 100 {
+		require.NoError(t, slow.Wait(context.Background(), fmt.Sprintf("$aact_busy_%d", i), "customers"))
+	}
+	require.Equal(t, 100, slow.Size(), "buckets still refilling must be kept")
+}
+
+func TestEndpointFamilyShouldUseFirstResourceAfterVersion(t *testing.T) {
+	require.Equal(t, "customers", rest_asaas.EndpointFamily("https://api-sandbox.asaas.com/v3/customers/cus_1?offset=10"))
+	require.Equal(t, "subscriptions", rest_asaas.EndpointFamily("/v3/subscriptions"))
+	require.Equal(t, "payments", rest_asaas.EndpointFamily("/payments/pay_1"))
+}
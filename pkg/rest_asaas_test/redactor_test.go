@@ -0,0 +1,77 @@
+package rest_asaas_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pericles-luz/go-asaas/pkg/model"
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorShouldMaskDefaultKeys(t *testing.T) {
+	redactor := rest_asaas.NewDefaultRedactor()
+	payload := map[string]interface{}{
+		"name":        "John Doe",
+		"cpfCnpj":     "00000000191",
+		"mobilePhone": "31999999999",
+		"creditCard": map[string]interface{}{
+			"number": "5162306219378829",
+		},
+	}
+	result := redactor.Map(payload)
+	require.Equal(t, "John Doe", result["name"])
+	require.Equal(t, rest_asaas.REDACTED, result["cpfCnpj"])
+	require.Equal(t, rest_asaas.REDACTED, result["mobilePhone"])
+	require.Equal(t, rest_asaas.REDACTED, result["creditCard"])
+	require.Equal(t, "00000000191", payload["cpfCnpj"], "original payload should not be changed")
+}
+
+func TestRedactorShouldMaskNestedJSON(t *testing.T) {
+	redactor := rest_asaas.NewDefaultRedactor()
+	result := redactor.JSON(`{"data":[{"id":"cus_1","Email":"john@doe.com","cpfCnpj":"00000000191"}]}`)
+	require.Equal(t, `{"data":[{"Email":"[REDACTED]","cpfCnpj":"[REDACTED]","id":"cus_1"}]}`, result)
+	require.Equal(t, rest_asaas.REDACTED, redactor.JSON(`<html>00000000191</html>`), "non JSON bodies should be masked")
+}
+
+func TestRedactorShouldFollowConfiguredRules(t *testing.T) {
+	redactor := rest_asaas.NewRedactor("name").SetMask("***")
+	result := redactor.Map(map[string]interface{}{"name": "John Doe", "cpfCnpj": "00000000191"})
+	require.Equal(t, "***", result["name"])
+	require.Equal(t, "00000000191", result["cpfCnpj"])
+	redactor.RemoveKeys("name")
+	require.False(t, redactor.IsRedacted("name"))
+	headers := rest_asaas.NewDefaultRedactor().Headers(map[string]string{"access_token": "$aact_test", "accept": "application/json"})
+	require.Equal(t, rest_asaas.REDACTED, headers["access_token"])
+	require.Equal(t, "application/json", headers["accept"])
+}
+
+func TestRestShouldNotLogSensitiveData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"invalid_mobilePhone","description":"invalid"}],"mobilePhone":"31999999999"}`))
+	}))
+	defer server.Close()
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	engine.SetLogger(logger)
+	restEntity, err := rest_asaas.NewRest(engine, writeCredential(t, server.URL))
+	require.NoError(t, err, "Failed to create rest entity")
+	restEntity.SetLogger(logger)
+	customer := model.NewCustomer().
+		SetName("John Doe").
+		SetCpfCnpj("00000000191").
+		SetMobilePhone("31999999999")
+	_, err = restEntity.CreateCustomer(context.Background(), customer)
+	require.Error(t, err)
+	require.Contains(t, buffer.String(), "asaas request")
+	require.Contains(t, buffer.String(), "John Doe")
+	require.NotContains(t, buffer.String(), "00000000191")
+	require.NotContains(t, buffer.String(), "31999999999")
+	require.NotContains(t, buffer.String(), "$aact_test")
+}
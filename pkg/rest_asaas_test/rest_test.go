@@ -1,27 +1,21 @@
 package rest_asaas_test
 
 import (
-	"os"
+	"context"
 	"testing"
-	"time"
 
-	"github.com/pericles-luz/go-asaas/pkg/factory/factory_client_asaas"
+	"github.com/pericles-luz/go-asaas/pkg/asaastest"
 	"github.com/pericles-luz/go-asaas/pkg/model"
-	"github.com/pericles-luz/go-base/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
 
 func TestRestShouldCreateCustomer(t *testing.T) {
-	if os.Getenv("GITHUB_ACTIONS") == "yes" {
-		t.Skip("Skip test in GitHub Actions")
-	}
-	restEntity, err := factory_client_asaas.NewClient(utils.GetBaseDirectory("config") + "/sandbox.json")
-	require.NoError(t, err, "Failed to create rest entity")
+	_, restEntity := newFakeServer(t)
 	customer := model.NewCustomer().
 		SetName("John Doe").
 		SetCpfCnpj("00000000191").
-		SetMobilePhone("31999999999") // replace with a valid mobile phone number
-	created, err := restEntity.CreateCustomer(customer)
+		SetMobilePhone("31999999999")
+	created, err := restEntity.CreateCustomer(context.Background(), customer)
 	require.NoError(t, err, "Failed to create customer")
 	require.Equal(t, customer.Name, created.Name, "Customer name should match")
 	require.Equal(t, customer.CpfCnpj, created.CpfCnpj, "Customer CPF/CNPJ should match")
@@ -30,45 +24,34 @@ func TestRestShouldCreateCustomer(t *testing.T) {
 }
 
 func TestRestShouldRetrieveCustomer(t *testing.T) {
-	if os.Getenv("GITHUB_ACTIONS") == "yes" {
-		t.Skip("Skip test in GitHub Actions")
-	}
-	restEntity, err := factory_client_asaas.NewClient(utils.GetBaseDirectory("config") + "/sandbox.json")
-	require.NoError(t, err, "Failed to create rest entity")
-	customerID := "cus_000006724433" // Replace with a valid customer ID
-	customer, err := restEntity.GetCustomer(customerID)
+	server, restEntity := newFakeServer(t)
+	customerID := seedCustomer(server, "John Doe")
+	customer, err := restEntity.GetCustomer(context.Background(), customerID)
 	require.NoError(t, err, "Failed to retrieve customer")
 	require.Equal(t, customerID, customer.ID, "Customer ID should match")
 	require.NotEmpty(t, customer.Name, "Customer name should not be empty")
 }
 
 func TestRestShouldListCustomers(t *testing.T) {
-	if os.Getenv("GITHUB_ACTIONS") == "yes" {
-		t.Skip("Skip test in GitHub Actions")
-	}
-	restEntity, err := factory_client_asaas.NewClient(utils.GetBaseDirectory("config") + "/sandbox.json")
-	require.NoError(t, err, "Failed to create rest entity")
-	customers, err := restEntity.ListCustomers(map[string]interface{}{
-		"name": "John Doe", // Adjust the filter as needed
-	})
+	server, restEntity := newFakeServer(t)
+	seedCustomer(server, "John Doe")
+	seedCustomer(server, "Jane Doe")
+	customers, err := restEntity.ListCustomers(context.Background(), model.NewCustomerFilter().SetName("John Doe"))
 	require.NoError(t, err, "Failed to list customers")
 	require.NotEmpty(t, customers, "Customers list should not be empty")
+	require.Len(t, customers.Data, 1, "only the matching customer should be listed")
 }
 
 func TestRestShouldSubscribe(t *testing.T) {
-	if os.Getenv("GITHUB_ACTIONS") == "yes" {
-		t.Skip("Skip test in GitHub Actions")
-	}
-	restEntity, err := factory_client_asaas.NewClient(utils.GetBaseDirectory("config") + "/sandbox.json")
-	require.NoError(t, err, "Failed to create rest entity")
+	server, restEntity := newFakeServer(t)
 	subscription := model.NewSubscription().
-		SetCustomerID("cus_000006724433"). // Replace with a valid customer ID
+		SetCustomerID(seedCustomer(server, "John Doe")).
 		SetBillingType(model.BILLING_TYPE_BOLETO).
-		SetNextDueDate(time.Now().AddDate(0, 1, 0).Format("2006-01-02")).
-		SetValue(100.00).
+		SetNextDueDate(model.Today().AddDate(0, 1, 0)).
+		SetValue(model.Cents(1999)).
 		SetCycle(model.CYCLE_MONTHLY).
 		SetDescription("Monthly Subscription for John Doe")
-	createdSubscription, err := restEntity.Subscribe(subscription)
+	createdSubscription, err := restEntity.Subscribe(context.Background(), subscription)
 	require.NoError(t, err, "Failed to create subscription")
 	require.Equal(t, subscription.CustomerID, createdSubscription.CustomerID, "Subscription customer ID should match")
 	require.Equal(t, subscription.BillingType, createdSubscription.BillingType, "Subscription billing type should match")
@@ -80,12 +63,27 @@ func TestRestShouldSubscribe(t *testing.T) {
 }
 
 func TestRestShouldUnsubscribe(t *testing.T) {
-	if os.Getenv("GITHUB_ACTIONS") == "yes" {
-		t.Skip("Skip test in GitHub Actions")
-	}
-	restEntity, err := factory_client_asaas.NewClient(utils.GetBaseDirectory("config") + "/sandbox.json")
-	require.NoError(t, err, "Failed to create rest entity")
-	subscriptionID := "sub_1ifrhps9m8mwficw" // Replace with a valid subscription ID
-	err = restEntity.Unsubscribe(subscriptionID)
+	server, restEntity := newFakeServer(t)
+	subscriptionID := server.Seed(asaastest.RESOURCE_SUBSCRIPTIONS, map[string]interface{}{
+		"customer": seedCustomer(server, "John Doe"),
+		"status":   "ACTIVE",
+	})
+	err := restEntity.Unsubscribe(context.Background(), subscriptionID)
 	require.NoError(t, err, "Failed to unsubscribe")
+	stored, _ := server.Get(asaastest.RESOURCE_SUBSCRIPTIONS, subscriptionID)
+	require.Equal(t, true, stored["deleted"], "subscription should be deleted")
+}
+
+func TestRestShouldRetrievePixSubscription(t *testing.T) {
+	server, restEntity := newFakeServer(t)
+	subscriptionID := server.Seed(asaastest.RESOURCE_SUBSCRIPTIONS, map[string]interface{}{
+		"customer":    seedCustomer(server, "John Doe"),
+		"billingType": "PIX",
+		"nextDueDate": "2025-07-24",
+		"value":       100,
+		"cycle":       "MONTHLY",
+	})
+	subscription, err := restEntity.GetSubscription(context.Background(), subscriptionID)
+	require.NoError(t, err, "subscriptions created elsewhere should be read")
+	require.Equal(t, model.BILLING_TYPE_PIX, subscription.BillingType)
 }
@@ -0,0 +1,158 @@
+package rest_asaas_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/rest_asaas"
+	"github.com/stretchr/testify/require"
+)
+
+// creates a server that fails with the given status until the given number of calls is reached
+func newFlakyServer(t *testing.T, failures int32, status int, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newFastRetryEngine() *rest_asaas.Engine {
+	return newRetryEngine(5 * time.Millisecond)
+}
+
+// creates an engine whose waits, including Retry-After, are capped by maxDelay
+func newRetryEngine(maxDelay time.Duration) *rest_asaas.Engine {
+	engine := rest_asaas.NewEngine(rest_asaas.Config{})
+	policy := rest_asaas.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = maxDelay
+	engine.SetRetryPolicy(policy)
+	return engine
+}
+
+func TestEngineShouldRetryGetOnServerError(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := newFlakyServer(t, 2, http.StatusServiceUnavailable, calls)
+	result, err := newFastRetryEngine().GetWithHeaderNoAuth(context.Background(), nil, server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.Equal(t, 2, result.GetRetries(), "retries should be exposed in the response")
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestEngineShouldStopRetryingAfterMaxAttempts(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := newFlakyServer(t, 10, http.StatusTooManyRequests, calls)
+	result, err := newFastRetryEngine().DeleteWithHeaderNoAuth(context.Background(), server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, result.GetCode())
+	require.Equal(t, 2, result.GetRetries())
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestEngineShouldNotRetryPostByDefault(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := newFlakyServer(t, 1, http.StatusInternalServerError, calls)
+	result, err := newFastRetryEngine().PostWithHeaderNoAuth(context.Background(), map[string]interface{}{}, server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusInternalServerError, result.GetCode())
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestEngineShouldRetryPostWithIdempotencyKey(t *testing.T) {
+	calls := &atomic.Int32{}
+	keys := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get(rest_asaas.IDEMPOTENCY_KEY_HEADER)
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := rest_asaas.WithIdempotencyKey(context.Background(), "order-42")
+	result, err := newFastRetryEngine().PostWithHeaderNoAuth(ctx, map[string]interface{}{}, server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.Equal(t, 1, result.GetRetries())
+	require.Equal(t, "order-42", <-keys)
+	require.Equal(t, "order-42", <-keys)
+}
+
+func TestEngineShouldRetryPostWhenCallerOptsIn(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := newFlakyServer(t, 1, http.StatusInternalServerError, calls)
+	engine := newFastRetryEngine()
+	policy := rest_asaas.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.RetryNonIdempotent = true
+	engine.SetRetryPolicy(policy)
+	result, err := engine.PostWithHeaderNoAuth(context.Background(), map[string]interface{}{}, server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestEngineShouldHonorRetryAfter(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	start := time.Now()
+	result, err := newRetryEngine(2*time.Second).GetWithHeaderNoAuth(context.Background(), nil, server.URL, map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.GreaterOrEqual(t, time.Since(start), time.Second, "should wait for Retry-After")
+}
+
+func TestEngineShouldCapRetryAfterWithMaxDelay(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := newFastRetryEngine().GetWithHeaderNoAuth(ctx, nil, server.URL, map[string]string{})
+	require.NoError(t, err, "a day long Retry-After should be capped by MaxDelay")
+	require.Equal(t, http.StatusOK, result.GetCode())
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestEngineShouldStopRetryingWhenContextIsDone(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := newRetryEngine(time.Minute).GetWithHeaderNoAuth(ctx, nil, server.URL, map[string]string{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(1), calls.Load())
+}
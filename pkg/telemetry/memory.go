@@ -0,0 +1,168 @@
+package telemetry
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// default histogram bounds for request latencies
+var DefaultLatencyBounds = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// RecordedSpan is a finished span kept by Memory
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]any
+	Errors     []error
+	Start      time.Time
+	End        time.Time
+}
+
+func (s RecordedSpan) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Measurement is a duration kept by Memory
+type Measurement struct {
+	Name       string
+	Duration   time.Duration
+	Attributes map[string]any
+}
+
+// Memory is an in-memory Tracer and Meter, meant for tests
+type Memory struct {
+	mutex        sync.Mutex
+	spans        []RecordedSpan
+	measurements []Measurement
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, &memorySpan{
+		memory: m,
+		span: RecordedSpan{
+			Name:       name,
+			Attributes: map[string]any{},
+			Start:      time.Now(),
+		},
+	}
+}
+
+func (m *Memory) RecordDuration(ctx context.Context, name string, duration time.Duration, attributes map[string]any) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	copied := make(map[string]any, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	m.measurements = append(m.measurements, Measurement{
+		Name:       name,
+		Duration:   duration,
+		Attributes: copied,
+	})
+}
+
+// returns the finished spans, in the order they ended
+func (m *Memory) Spans() []RecordedSpan {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]RecordedSpan{}, m.spans...)
+}
+
+// returns the recorded durations, in the order they were recorded
+func (m *Memory) Measurements() []Measurement {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]Measurement{}, m.measurements...)
+}
+
+// counts the durations recorded under name and matching every given
+// attribute into buckets: counts[i] holds durations up to bounds[i] and the
+// last position holds the ones above every bound
+func (m *Memory) Histogram(name string, bounds []time.Duration, attributes map[string]any) []int {
+	counts := make([]int, len(bounds)+1)
+	for _, measurement := range m.Measurements() {
+		if measurement.Name != name || !matches(measurement.Attributes, attributes) {
+			continue
+		}
+		bucket := len(bounds)
+		for i, bound := range bounds {
+			if measurement.Duration <= bound {
+				bucket = i
+				break
+			}
+		}
+		counts[bucket]++
+	}
+	return counts
+}
+
+// discards everything recorded so far
+func (m *Memory) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.spans = nil
+	m.measurements = nil
+}
+
+func (m *Memory) finish(span RecordedSpan) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.spans = append(m.spans, span)
+}
+
+// compares with reflect.DeepEqual, since attributes may hold slices or maps
+// that would panic with ==
+func matches(attributes map[string]any, filter map[string]any) bool {
+	for key, value := range filter {
+		if !reflect.DeepEqual(attributes[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+type memorySpan struct {
+	mutex  sync.Mutex
+	memory *Memory
+	span   RecordedSpan
+	ended  bool
+}
+
+func (s *memorySpan) SetAttribute(key string, value any) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.span.Attributes[key] = value
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *memorySpan) End() {
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.span.End = time.Now()
+	span := s.span
+	s.mutex.Unlock()
+	s.memory.finish(span)
+}
@@ -0,0 +1,63 @@
+// Package telemetry defines the small tracing and metrics interfaces used to
+// instrument the Asaas client, so adapters for OpenTelemetry or any other
+// backend can be plugged in without the client depending on them.
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ATTRIBUTE_OPERATION   = "asaas.operation"
+	ATTRIBUTE_STATUS_CODE = "http.status_code"
+	ATTRIBUTE_ERROR_CODES = "asaas.error_codes"
+	ATTRIBUTE_RETRIES     = "asaas.retries"
+
+	METRIC_DURATION = "asaas.client.duration"
+)
+
+// Tracer starts a span for each operation
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// Meter records the duration of each operation, usually into a histogram
+type Meter interface {
+	RecordDuration(ctx context.Context, name string, duration time.Duration, attributes map[string]any)
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value any) {}
+
+func (noopSpan) RecordError(err error) {}
+
+func (noopSpan) End() {}
+
+type noopMeter struct{}
+
+func (noopMeter) RecordDuration(ctx context.Context, name string, duration time.Duration, attributes map[string]any) {
+}
+
+// returns a tracer that records nothing
+func NewNoopTracer() Tracer {
+	return noopTracer{}
+}
+
+// returns a meter that records nothing
+func NewNoopMeter() Meter {
+	return noopMeter{}
+}
@@ -0,0 +1,54 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pericles-luz/go-asaas/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryShouldRecordSpans(t *testing.T) {
+	memory := telemetry.NewMemory()
+	_, span := memory.Start(context.Background(), "asaas.GetCustomer")
+	span.SetAttribute(telemetry.ATTRIBUTE_STATUS_CODE, 404)
+	span.RecordError(errors.New("customer not found"))
+	require.Empty(t, memory.Spans(), "span should only be recorded when it ends")
+	span.End()
+	span.End()
+	spans := memory.Spans()
+	require.Len(t, spans, 1, "ending twice should record once")
+	require.Equal(t, "asaas.GetCustomer", spans[0].Name)
+	require.Equal(t, 404, spans[0].Attributes[telemetry.ATTRIBUTE_STATUS_CODE])
+	require.Len(t, spans[0].Errors, 1)
+	require.GreaterOrEqual(t, spans[0].Duration(), time.Duration(0))
+}
+
+func TestMemoryShouldBuildHistogram(t *testing.T) {
+	memory := telemetry.NewMemory()
+	bounds := []time.Duration{100 * time.Millisecond, time.Second}
+	attributes := map[string]any{telemetry.ATTRIBUTE_OPERATION: "GetCustomer"}
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 50*time.Millisecond, attributes)
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 100*time.Millisecond, attributes)
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 500*time.Millisecond, attributes)
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 3*time.Second, attributes)
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 3*time.Second, map[string]any{telemetry.ATTRIBUTE_OPERATION: "Subscribe"})
+	require.Equal(t, []int{2, 1, 1}, memory.Histogram(telemetry.METRIC_DURATION, bounds, attributes))
+	require.Equal(t, []int{2, 1, 2}, memory.Histogram(telemetry.METRIC_DURATION, bounds, nil))
+	memory.Reset()
+	require.Empty(t, memory.Measurements())
+}
+
+func TestMemoryShouldFilterByNonComparableAttributes(t *testing.T) {
+	memory := telemetry.NewMemory()
+	attributes := map[string]any{telemetry.ATTRIBUTE_OPERATION: "ListCustomers", "asaas.error_codes": []string{"invalid_limit"}}
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 50*time.Millisecond, attributes)
+	memory.RecordDuration(context.Background(), telemetry.METRIC_DURATION, 50*time.Millisecond, map[string]any{"asaas.error_codes": map[string]int{"x": 1}})
+	bounds := []time.Duration{time.Second}
+	require.NotPanics(t, func() {
+		require.Equal(t, []int{1, 0}, memory.Histogram(telemetry.METRIC_DURATION, bounds, map[string]any{"asaas.error_codes": []string{"invalid_limit"}}))
+	})
+	require.Equal(t, []int{0, 0}, memory.Histogram(telemetry.METRIC_DURATION, bounds, map[string]any{"asaas.error_codes": []string{"other"}}))
+}